@@ -0,0 +1,132 @@
+/*
+ * Copyright (c) 2014 Michael Wendland
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+ * FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+ * IN THE SOFTWARE.
+ *
+ * 	Authors:
+ * 		Michael Wendland <michael@michiwend.com>
+ */
+
+package gomusicbrainz
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestParseDigestChallenge(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		want   map[string]string
+	}{
+		{
+			name:   "realm and nonce",
+			header: `Digest realm="musicbrainz.org", nonce="abc123"`,
+			want:   map[string]string{"realm": "musicbrainz.org", "nonce": "abc123"},
+		},
+		{
+			name:   "with qop",
+			header: `Digest realm="musicbrainz.org", nonce="abc123", qop="auth"`,
+			want:   map[string]string{"realm": "musicbrainz.org", "nonce": "abc123", "qop": "auth"},
+		},
+		{
+			name:   "malformed part is ignored",
+			header: `Digest realm="musicbrainz.org", garbage, nonce="abc123"`,
+			want:   map[string]string{"realm": "musicbrainz.org", "nonce": "abc123"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseDigestChallenge(tt.header)
+			for k, want := range tt.want {
+				if got[k] != want {
+					t.Errorf("params[%q] = %q, want %q", k, got[k], want)
+				}
+			}
+		})
+	}
+}
+
+// TestDigestAuthRetriesWithCredentials proves DigestAuth replies to a 401
+// challenge with a second request carrying a computed Authorization
+// header, rather than surfacing the 401 to the caller.
+func TestDigestAuthRetriesWithCredentials(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.Header().Set("WWW-Authenticate", `Digest realm="musicbrainz.org", nonce="testnonce"`)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		auth := r.Header.Get("Authorization")
+		if !strings.HasPrefix(auth, "Digest ") || !strings.Contains(auth, `username="user"`) {
+			t.Errorf("second request Authorization = %q, want a Digest header for user", auth)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	req, err := http.NewRequest("GET", server.URL, nil)
+	if err != nil {
+		t.Fatalf("NewRequest: %v", err)
+	}
+
+	auth := DigestAuth{User: "user", Password: "pass"}
+	resp, err := auth.authenticatedDo(&http.Client{}, req)
+	if err != nil {
+		t.Fatalf("authenticatedDo: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if attempts != 2 {
+		t.Errorf("server saw %d requests, want 2 (challenge + authenticated retry)", attempts)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("final status = %d, want 200", resp.StatusCode)
+	}
+}
+
+// TestOAuth2AuthSetsBearerHeader proves OAuth2Auth sets the Authorization
+// header from its TokenSource before issuing the request.
+func TestOAuth2AuthSetsBearerHeader(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got, want := r.Header.Get("Authorization"), "Bearer my-token"; got != want {
+			t.Errorf("Authorization = %q, want %q", got, want)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	req, err := http.NewRequest("GET", server.URL, nil)
+	if err != nil {
+		t.Fatalf("NewRequest: %v", err)
+	}
+
+	auth := OAuth2Auth{TokenSource: staticTokenSource("my-token")}
+	resp, err := auth.authenticatedDo(&http.Client{}, req)
+	if err != nil {
+		t.Fatalf("authenticatedDo: %v", err)
+	}
+	resp.Body.Close()
+}
@@ -0,0 +1,215 @@
+/*
+ * Copyright (c) 2014 Michael Wendland
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+ * FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+ * IN THE SOFTWARE.
+ *
+ * 	Authors:
+ * 		Michael Wendland <michael@michiwend.com>
+ */
+
+package gomusicbrainz
+
+import "context"
+
+// browseParams builds the query parameters shared by every browse request:
+// the MBID of the linked entity to browse by, pagination, and inc=
+// subqueries.
+func browseParams(relation, mbid string, page PageSettings, inc []string) map[string][]string {
+	params := incParams(inc)
+	params.Set(relation, mbid)
+	params.Set("limit", intParamToString(page.Limit))
+	params.Set("offset", intParamToString(page.Offset))
+	return params
+}
+
+// BrowseReleasesByArtist returns all Releases linked to the Artist
+// identified by artistMBID. page controls pagination as described on the
+// Search methods.
+func (c *WS2Client) BrowseReleasesByArtist(artistMBID string, page PageSettings, inc ...string) (*ReleaseResponse, error) {
+	result := releaseResult{}
+	params := browseParams("artist", artistMBID, page, inc)
+
+	if err := c.getReqeust(&result, params, "/release"); err != nil {
+		return nil, err
+	}
+	return &result.Response, nil
+}
+
+// BrowseReleaseGroupsByArtist returns all ReleaseGroups linked to the Artist
+// identified by artistMBID.
+func (c *WS2Client) BrowseReleaseGroupsByArtist(artistMBID string, page PageSettings, inc ...string) (*ReleaseGroupResponse, error) {
+	result := releaseGroupResult{}
+	params := browseParams("artist", artistMBID, page, inc)
+
+	if err := c.getReqeust(&result, params, "/release-group"); err != nil {
+		return nil, err
+	}
+	return &result.Response, nil
+}
+
+// BrowseRecordingsByArtist returns all Recordings linked to the Artist
+// identified by artistMBID.
+func (c *WS2Client) BrowseRecordingsByArtist(artistMBID string, page PageSettings, inc ...string) (*RecordingResponse, error) {
+	result := recordingResult{}
+	params := browseParams("artist", artistMBID, page, inc)
+
+	if err := c.getReqeust(&result, params, "/recording"); err != nil {
+		return nil, err
+	}
+	return &result.Response, nil
+}
+
+// BrowseWorksByArtist returns all Works linked to the Artist identified by
+// artistMBID.
+func (c *WS2Client) BrowseWorksByArtist(artistMBID string, page PageSettings, inc ...string) (*WorkResponse, error) {
+	result := workResult{}
+	params := browseParams("artist", artistMBID, page, inc)
+
+	if err := c.getReqeust(&result, params, "/work"); err != nil {
+		return nil, err
+	}
+	return &result.Response, nil
+}
+
+// BrowseReleasesByReleaseGroup returns all Releases linked to the
+// ReleaseGroup identified by releaseGroupMBID.
+func (c *WS2Client) BrowseReleasesByReleaseGroup(releaseGroupMBID string, page PageSettings, inc ...string) (*ReleaseResponse, error) {
+	result := releaseResult{}
+	params := browseParams("release-group", releaseGroupMBID, page, inc)
+
+	if err := c.getReqeust(&result, params, "/release"); err != nil {
+		return nil, err
+	}
+	return &result.Response, nil
+}
+
+// BrowseReleasesByLabel returns all Releases linked to the Label identified
+// by labelMBID.
+func (c *WS2Client) BrowseReleasesByLabel(labelMBID string, page PageSettings, inc ...string) (*ReleaseResponse, error) {
+	result := releaseResult{}
+	params := browseParams("label", labelMBID, page, inc)
+
+	if err := c.getReqeust(&result, params, "/release"); err != nil {
+		return nil, err
+	}
+	return &result.Response, nil
+}
+
+// BrowseArtistsByRecording returns all Artists credited on the Recording
+// identified by recordingMBID.
+func (c *WS2Client) BrowseArtistsByRecording(recordingMBID string, page PageSettings, inc ...string) (*ArtistResponse, error) {
+	result := artistResult{}
+	params := browseParams("recording", recordingMBID, page, inc)
+
+	if err := c.getReqeust(&result, params, "/artist"); err != nil {
+		return nil, err
+	}
+	return &result.Resonse, nil
+}
+
+// BrowseReleasesByArtistContext is like BrowseReleasesByArtist but takes a context.Context that can
+// cancel the request, e.g. while the client is blocked on the rate limiter.
+func (c *WS2Client) BrowseReleasesByArtistContext(ctx context.Context, artistMBID string, page PageSettings, inc ...string) (*ReleaseResponse, error) {
+	result := releaseResult{}
+	params := browseParams("artist", artistMBID, page, inc)
+
+	if err := c.getReqeustContext(ctx, &result, params, "/release"); err != nil {
+		return nil, err
+	}
+	return &result.Response, nil
+
+}
+
+// BrowseReleaseGroupsByArtistContext is like BrowseReleaseGroupsByArtist but takes a context.Context that can
+// cancel the request, e.g. while the client is blocked on the rate limiter.
+func (c *WS2Client) BrowseReleaseGroupsByArtistContext(ctx context.Context, artistMBID string, page PageSettings, inc ...string) (*ReleaseGroupResponse, error) {
+	result := releaseGroupResult{}
+	params := browseParams("artist", artistMBID, page, inc)
+
+	if err := c.getReqeustContext(ctx, &result, params, "/release-group"); err != nil {
+		return nil, err
+	}
+	return &result.Response, nil
+
+}
+
+// BrowseRecordingsByArtistContext is like BrowseRecordingsByArtist but takes a context.Context that can
+// cancel the request, e.g. while the client is blocked on the rate limiter.
+func (c *WS2Client) BrowseRecordingsByArtistContext(ctx context.Context, artistMBID string, page PageSettings, inc ...string) (*RecordingResponse, error) {
+	result := recordingResult{}
+	params := browseParams("artist", artistMBID, page, inc)
+
+	if err := c.getReqeustContext(ctx, &result, params, "/recording"); err != nil {
+		return nil, err
+	}
+	return &result.Response, nil
+
+}
+
+// BrowseWorksByArtistContext is like BrowseWorksByArtist but takes a context.Context that can
+// cancel the request, e.g. while the client is blocked on the rate limiter.
+func (c *WS2Client) BrowseWorksByArtistContext(ctx context.Context, artistMBID string, page PageSettings, inc ...string) (*WorkResponse, error) {
+	result := workResult{}
+	params := browseParams("artist", artistMBID, page, inc)
+
+	if err := c.getReqeustContext(ctx, &result, params, "/work"); err != nil {
+		return nil, err
+	}
+	return &result.Response, nil
+
+}
+
+// BrowseReleasesByReleaseGroupContext is like BrowseReleasesByReleaseGroup but takes a context.Context that can
+// cancel the request, e.g. while the client is blocked on the rate limiter.
+func (c *WS2Client) BrowseReleasesByReleaseGroupContext(ctx context.Context, releaseGroupMBID string, page PageSettings, inc ...string) (*ReleaseResponse, error) {
+	result := releaseResult{}
+	params := browseParams("release-group", releaseGroupMBID, page, inc)
+
+	if err := c.getReqeustContext(ctx, &result, params, "/release"); err != nil {
+		return nil, err
+	}
+	return &result.Response, nil
+
+}
+
+// BrowseReleasesByLabelContext is like BrowseReleasesByLabel but takes a context.Context that can
+// cancel the request, e.g. while the client is blocked on the rate limiter.
+func (c *WS2Client) BrowseReleasesByLabelContext(ctx context.Context, labelMBID string, page PageSettings, inc ...string) (*ReleaseResponse, error) {
+	result := releaseResult{}
+	params := browseParams("label", labelMBID, page, inc)
+
+	if err := c.getReqeustContext(ctx, &result, params, "/release"); err != nil {
+		return nil, err
+	}
+	return &result.Response, nil
+
+}
+
+// BrowseArtistsByRecordingContext is like BrowseArtistsByRecording but takes a context.Context that can
+// cancel the request, e.g. while the client is blocked on the rate limiter.
+func (c *WS2Client) BrowseArtistsByRecordingContext(ctx context.Context, recordingMBID string, page PageSettings, inc ...string) (*ArtistResponse, error) {
+	result := artistResult{}
+	params := browseParams("recording", recordingMBID, page, inc)
+
+	if err := c.getReqeustContext(ctx, &result, params, "/artist"); err != nil {
+		return nil, err
+	}
+	return &result.Resonse, nil
+
+}
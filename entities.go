@@ -0,0 +1,470 @@
+/*
+ * Copyright (c) 2014 Michael Wendland
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+ * FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+ * IN THE SOFTWARE.
+ *
+ * 	Authors:
+ * 		Michael Wendland <michael@michiwend.com>
+ */
+
+package gomusicbrainz
+
+import (
+	"encoding/json"
+	"encoding/xml"
+)
+
+// Artist represents a person or a group of people used to create music.
+type Artist struct {
+	ID             string `xml:"id,attr" json:"id"`
+	Name           string `xml:"name" json:"name"`
+	SortName       string `xml:"sort-name" json:"sort-name"`
+	Disambiguation string `xml:"disambiguation" json:"disambiguation,omitempty"`
+	Type           string `xml:"type,attr" json:"type,omitempty"`
+	Gender         string `xml:"gender" json:"gender,omitempty"`
+	Country        string `xml:"country" json:"country,omitempty"`
+
+	// Tags, Rating and Aliases are only populated when the request
+	// included the matching inc= subquery ("tags", "ratings",
+	// "aliases"). Other inc= subqueries (e.g. "artist-rels",
+	// "recordings", "release-groups") are accepted as query parameters
+	// but their response data isn't modeled yet and is dropped by the
+	// decoder.
+	Tags    []Tag   `xml:"tag-list>tag" json:"tags,omitempty"`
+	Rating  *Rating `xml:"rating" json:"rating,omitempty"`
+	Aliases []Alias `xml:"alias-list>alias" json:"aliases,omitempty"`
+}
+
+// artistResult wraps the <metadata> envelope returned by the Search Server
+// for artist queries.
+type artistResult struct {
+	XMLName xml.Name       `xml:"metadata"`
+	Resonse ArtistResponse `xml:"artist-list"`
+}
+
+// UnmarshalJSON decodes straight into Resonse: unlike the XML <metadata>
+// envelope, MusicBrainz's JSON responses put count/offset/artists at the
+// top level with no wrapping object.
+func (r *artistResult) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, &r.Resonse)
+}
+
+// ArtistResponse is the result of an Artist search/browse/lookup request.
+type ArtistResponse struct {
+	Count   int      `xml:"count,attr" json:"count"`
+	Offset  int      `xml:"offset,attr" json:"offset"`
+	Artists []Artist `xml:"artist" json:"artists"`
+}
+
+// Release represents a unique release of an album.
+type Release struct {
+	ID      string `xml:"id,attr" json:"id"`
+	Title   string `xml:"title" json:"title"`
+	Status  string `xml:"status" json:"status,omitempty"`
+	Date    string `xml:"date" json:"date,omitempty"`
+	Country string `xml:"country" json:"country,omitempty"`
+
+	// Tags and Rating are only populated when requested via inc=; see
+	// Artist.Tags.
+	Tags   []Tag   `xml:"tag-list>tag" json:"tags,omitempty"`
+	Rating *Rating `xml:"rating" json:"rating,omitempty"`
+}
+
+type releaseResult struct {
+	XMLName  xml.Name        `xml:"metadata"`
+	Response ReleaseResponse `xml:"release-list"`
+}
+
+// UnmarshalJSON decodes straight into Response; see artistResult.UnmarshalJSON.
+func (r *releaseResult) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, &r.Response)
+}
+
+// ReleaseResponse is the result of a Release search/browse/lookup request.
+type ReleaseResponse struct {
+	Count    int       `xml:"count,attr" json:"count"`
+	Offset   int       `xml:"offset,attr" json:"offset"`
+	Releases []Release `xml:"release" json:"releases"`
+}
+
+// ReleaseGroup represents a set of releases that are grouped together, e.g.
+// different formats/editions of the same album.
+type ReleaseGroup struct {
+	ID               string `xml:"id,attr" json:"id"`
+	Title            string `xml:"title" json:"title"`
+	PrimaryType      string `xml:"primary-type" json:"primary-type,omitempty"`
+	FirstReleaseDate string `xml:"first-release-date" json:"first-release-date,omitempty"`
+
+	// Tags and Rating are only populated when requested via inc=; see
+	// Artist.Tags.
+	Tags   []Tag   `xml:"tag-list>tag" json:"tags,omitempty"`
+	Rating *Rating `xml:"rating" json:"rating,omitempty"`
+}
+
+type releaseGroupResult struct {
+	XMLName  xml.Name             `xml:"metadata"`
+	Response ReleaseGroupResponse `xml:"release-group-list"`
+}
+
+// UnmarshalJSON decodes straight into Response; see artistResult.UnmarshalJSON.
+func (r *releaseGroupResult) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, &r.Response)
+}
+
+// ReleaseGroupResponse is the result of a ReleaseGroup search/browse/lookup request.
+type ReleaseGroupResponse struct {
+	Count         int            `xml:"count,attr" json:"count"`
+	Offset        int            `xml:"offset,attr" json:"offset"`
+	ReleaseGroups []ReleaseGroup `xml:"release-group" json:"release-groups"`
+}
+
+// Tag represents a user-assigned keyword used to categorize an entity.
+type Tag struct {
+	Name  string `xml:"name" json:"name"`
+	Count int    `xml:"count,attr" json:"count"`
+}
+
+type tagResult struct {
+	XMLName  xml.Name    `xml:"metadata"`
+	Response TagResponse `xml:"tag-list"`
+}
+
+// UnmarshalJSON decodes straight into Response; see artistResult.UnmarshalJSON.
+func (r *tagResult) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, &r.Response)
+}
+
+// TagResponse is the result of a Tag search request.
+type TagResponse struct {
+	Count  int   `xml:"count,attr" json:"count"`
+	Offset int   `xml:"offset,attr" json:"offset"`
+	Tags   []Tag `xml:"tag" json:"tags"`
+}
+
+// Rating is the aggregate community rating for an entity, populated when
+// the lookup/browse/search request included inc=ratings. Value ranges
+// from 0 to 5 in half-point steps.
+type Rating struct {
+	Value      float64 `xml:",chardata" json:"value"`
+	VotesCount int     `xml:"votes-count,attr" json:"votes-count"`
+}
+
+// Alias is an alternate name for an entity, populated when the
+// lookup/browse/search request included inc=aliases.
+type Alias struct {
+	Name     string `xml:",chardata" json:"name"`
+	SortName string `xml:"sort-name,attr" json:"sort-name"`
+	Locale   string `xml:"locale,attr" json:"locale,omitempty"`
+	Type     string `xml:"type,attr" json:"type,omitempty"`
+	Primary  bool   `xml:"primary,attr" json:"primary,omitempty"`
+}
+
+// Recording represents a unique audio that has been used to produce at least
+// one released track through copying or mastering.
+type Recording struct {
+	ID     string `xml:"id,attr" json:"id"`
+	Title  string `xml:"title" json:"title"`
+	Length int    `xml:"length" json:"length,omitempty"`
+
+	// Tags and Rating are only populated when requested via inc=; see
+	// Artist.Tags.
+	Tags   []Tag   `xml:"tag-list>tag" json:"tags,omitempty"`
+	Rating *Rating `xml:"rating" json:"rating,omitempty"`
+}
+
+type recordingResult struct {
+	XMLName  xml.Name          `xml:"metadata"`
+	Response RecordingResponse `xml:"recording-list"`
+}
+
+// UnmarshalJSON decodes straight into Response; see artistResult.UnmarshalJSON.
+func (r *recordingResult) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, &r.Response)
+}
+
+// RecordingResponse is the result of a Recording search/browse/lookup request.
+type RecordingResponse struct {
+	Count      int         `xml:"count,attr" json:"count"`
+	Offset     int         `xml:"offset,attr" json:"offset"`
+	Recordings []Recording `xml:"recording" json:"recordings"`
+}
+
+// Work represents a distinct intellectual or artistic creation.
+type Work struct {
+	ID    string `xml:"id,attr" json:"id"`
+	Type  string `xml:"type,attr" json:"type,omitempty"`
+	Title string `xml:"title" json:"title"`
+
+	// Tags, Rating and Aliases are only populated when requested via
+	// inc=; see Artist.Tags.
+	Tags    []Tag   `xml:"tag-list>tag" json:"tags,omitempty"`
+	Rating  *Rating `xml:"rating" json:"rating,omitempty"`
+	Aliases []Alias `xml:"alias-list>alias" json:"aliases,omitempty"`
+}
+
+type workResult struct {
+	XMLName  xml.Name     `xml:"metadata"`
+	Response WorkResponse `xml:"work-list"`
+}
+
+// UnmarshalJSON decodes straight into Response; see artistResult.UnmarshalJSON.
+func (r *workResult) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, &r.Response)
+}
+
+// WorkResponse is the result of a Work search/browse/lookup request.
+type WorkResponse struct {
+	Count  int    `xml:"count,attr" json:"count"`
+	Offset int    `xml:"offset,attr" json:"offset"`
+	Works  []Work `xml:"work" json:"works"`
+}
+
+// Label represents a (usually) commercial entity that produces and sells
+// music, or a brand associated with such an entity.
+type Label struct {
+	ID      string `xml:"id,attr" json:"id"`
+	Name    string `xml:"name" json:"name"`
+	Type    string `xml:"type,attr" json:"type,omitempty"`
+	Country string `xml:"country" json:"country,omitempty"`
+
+	// Tags, Rating and Aliases are only populated when requested via
+	// inc=; see Artist.Tags.
+	Tags    []Tag   `xml:"tag-list>tag" json:"tags,omitempty"`
+	Rating  *Rating `xml:"rating" json:"rating,omitempty"`
+	Aliases []Alias `xml:"alias-list>alias" json:"aliases,omitempty"`
+}
+
+type labelResult struct {
+	XMLName  xml.Name      `xml:"metadata"`
+	Response LabelResponse `xml:"label-list"`
+}
+
+// UnmarshalJSON decodes straight into Response; see artistResult.UnmarshalJSON.
+func (r *labelResult) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, &r.Response)
+}
+
+// LabelResponse is the result of a Label search/browse/lookup request.
+type LabelResponse struct {
+	Count  int     `xml:"count,attr" json:"count"`
+	Offset int     `xml:"offset,attr" json:"offset"`
+	Labels []Label `xml:"label" json:"labels"`
+}
+
+// Area represents a geographic region or settlement.
+type Area struct {
+	ID   string `xml:"id,attr" json:"id"`
+	Name string `xml:"name" json:"name"`
+	Type string `xml:"type,attr" json:"type,omitempty"`
+
+	// Tags, Rating and Aliases are only populated when requested via
+	// inc=; see Artist.Tags.
+	Tags    []Tag   `xml:"tag-list>tag" json:"tags,omitempty"`
+	Rating  *Rating `xml:"rating" json:"rating,omitempty"`
+	Aliases []Alias `xml:"alias-list>alias" json:"aliases,omitempty"`
+}
+
+type areaResult struct {
+	XMLName  xml.Name     `xml:"metadata"`
+	Response AreaResponse `xml:"area-list"`
+}
+
+// UnmarshalJSON decodes straight into Response; see artistResult.UnmarshalJSON.
+func (r *areaResult) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, &r.Response)
+}
+
+// AreaResponse is the result of an Area search/browse/lookup request.
+type AreaResponse struct {
+	Count  int    `xml:"count,attr" json:"count"`
+	Offset int    `xml:"offset,attr" json:"offset"`
+	Areas  []Area `xml:"area" json:"areas"`
+}
+
+// Event represents an organized event where people can attend and/or
+// participate, e.g. a concert or a festival.
+type Event struct {
+	ID   string `xml:"id,attr" json:"id"`
+	Name string `xml:"name" json:"name"`
+	Type string `xml:"type,attr" json:"type,omitempty"`
+
+	// Tags and Rating are only populated when requested via inc=; see
+	// Artist.Tags.
+	Tags   []Tag   `xml:"tag-list>tag" json:"tags,omitempty"`
+	Rating *Rating `xml:"rating" json:"rating,omitempty"`
+}
+
+type eventResult struct {
+	XMLName  xml.Name      `xml:"metadata"`
+	Response EventResponse `xml:"event-list"`
+}
+
+// UnmarshalJSON decodes straight into Response; see artistResult.UnmarshalJSON.
+func (r *eventResult) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, &r.Response)
+}
+
+// EventResponse is the result of an Event search/browse/lookup request.
+type EventResponse struct {
+	Count  int     `xml:"count,attr" json:"count"`
+	Offset int     `xml:"offset,attr" json:"offset"`
+	Events []Event `xml:"event" json:"events"`
+}
+
+// Instrument represents a device created or adapted to make musical sounds.
+type Instrument struct {
+	ID          string `xml:"id,attr" json:"id"`
+	Name        string `xml:"name" json:"name"`
+	Type        string `xml:"type,attr" json:"type,omitempty"`
+	Description string `xml:"description" json:"description,omitempty"`
+
+	// Tags, Rating and Aliases are only populated when requested via
+	// inc=; see Artist.Tags.
+	Tags    []Tag   `xml:"tag-list>tag" json:"tags,omitempty"`
+	Rating  *Rating `xml:"rating" json:"rating,omitempty"`
+	Aliases []Alias `xml:"alias-list>alias" json:"aliases,omitempty"`
+}
+
+type instrumentResult struct {
+	XMLName  xml.Name           `xml:"metadata"`
+	Response InstrumentResponse `xml:"instrument-list"`
+}
+
+// UnmarshalJSON decodes straight into Response; see artistResult.UnmarshalJSON.
+func (r *instrumentResult) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, &r.Response)
+}
+
+// InstrumentResponse is the result of an Instrument search/browse/lookup request.
+type InstrumentResponse struct {
+	Count       int          `xml:"count,attr" json:"count"`
+	Offset      int          `xml:"offset,attr" json:"offset"`
+	Instruments []Instrument `xml:"instrument" json:"instruments"`
+}
+
+// Series represents a sequence of separate release groups, releases,
+// recordings, works, etc. with a common theme.
+type Series struct {
+	ID   string `xml:"id,attr" json:"id"`
+	Name string `xml:"name" json:"name"`
+	Type string `xml:"type,attr" json:"type,omitempty"`
+
+	// Tags, Rating and Aliases are only populated when requested via
+	// inc=; see Artist.Tags.
+	Tags    []Tag   `xml:"tag-list>tag" json:"tags,omitempty"`
+	Rating  *Rating `xml:"rating" json:"rating,omitempty"`
+	Aliases []Alias `xml:"alias-list>alias" json:"aliases,omitempty"`
+}
+
+type seriesResult struct {
+	XMLName  xml.Name       `xml:"metadata"`
+	Response SeriesResponse `xml:"series-list"`
+}
+
+// UnmarshalJSON decodes straight into Response; see artistResult.UnmarshalJSON.
+func (r *seriesResult) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, &r.Response)
+}
+
+// SeriesResponse is the result of a Series search/browse/lookup request.
+type SeriesResponse struct {
+	Count  int      `xml:"count,attr" json:"count"`
+	Offset int      `xml:"offset,attr" json:"offset"`
+	Series []Series `xml:"series" json:"series"`
+}
+
+// URL represents a URL used as a resource in relationships.
+type URL struct {
+	ID       string `xml:"id,attr" json:"id"`
+	Resource string `xml:"resource" json:"resource"`
+}
+
+// Annotation represents a freeform text comment attached to an entity.
+type Annotation struct {
+	Type string `xml:"type,attr" json:"type"`
+	Name string `xml:"name" json:"name"`
+	Text string `xml:"text" json:"text"`
+}
+
+type annotationResult struct {
+	XMLName  xml.Name           `xml:"metadata"`
+	Response AnnotationResponse `xml:"annotation-list"`
+}
+
+// UnmarshalJSON decodes straight into Response; see artistResult.UnmarshalJSON.
+func (r *annotationResult) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, &r.Response)
+}
+
+// AnnotationResponse is the result of an Annotation search request.
+type AnnotationResponse struct {
+	Count       int          `xml:"count,attr" json:"count"`
+	Offset      int          `xml:"offset,attr" json:"offset"`
+	Annotations []Annotation `xml:"annotation" json:"annotations"`
+}
+
+// CDStub represents a user-submitted track listing for a disc that hasn't
+// been matched to a full Release yet.
+type CDStub struct {
+	ID     string `xml:"id,attr" json:"id"`
+	Title  string `xml:"title" json:"title"`
+	Artist string `xml:"artist" json:"artist"`
+}
+
+type cdStubResult struct {
+	XMLName  xml.Name       `xml:"metadata"`
+	Response CDStubResponse `xml:"cdstub-list"`
+}
+
+// UnmarshalJSON decodes straight into Response; see artistResult.UnmarshalJSON.
+func (r *cdStubResult) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, &r.Response)
+}
+
+// CDStubResponse is the result of a CDStub search request.
+type CDStubResponse struct {
+	Count   int      `xml:"count,attr" json:"count"`
+	Offset  int      `xml:"offset,attr" json:"offset"`
+	CDStubs []CDStub `xml:"cdstub" json:"cdstubs"`
+}
+
+// UserRating is the authenticated user's own rating for an entity, as
+// returned by LookupUserRatings. WS2 ratings range from 0 (no rating) to
+// 100 in steps of 20, representing zero to five stars.
+type UserRating struct {
+	ID     string `xml:"id,attr" json:"id"`
+	Rating int    `xml:"user-rating" json:"user-rating"`
+}
+
+type urlResult struct {
+	XMLName  xml.Name    `xml:"metadata"`
+	Response URLResponse `xml:"url-list"`
+}
+
+// UnmarshalJSON decodes straight into Response; see artistResult.UnmarshalJSON.
+func (r *urlResult) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, &r.Response)
+}
+
+// URLResponse is the result of a URL search/browse/lookup request.
+type URLResponse struct {
+	Count  int   `xml:"count,attr" json:"count"`
+	Offset int   `xml:"offset,attr" json:"offset"`
+	URLs   []URL `xml:"url" json:"urls"`
+}
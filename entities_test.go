@@ -0,0 +1,67 @@
+/*
+ * Copyright (c) 2014 Michael Wendland
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+ * FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+ * IN THE SOFTWARE.
+ *
+ * 	Authors:
+ * 		Michael Wendland <michael@michiwend.com>
+ */
+
+package gomusicbrainz
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestLookupArtistIncFieldsJSON proves inc=tags+ratings+aliases populates
+// Artist.Tags/Rating/Aliases from a flat JSON lookup response.
+func TestLookupArtistIncFieldsJSON(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{
+			"id": "5441c29d-3602-4898-b1a1-b77fa23b8e50",
+			"name": "Queen",
+			"sort-name": "Queen",
+			"tags": [{"name": "rock", "count": 3}],
+			"rating": {"value": 4.5, "votes-count": 13},
+			"aliases": [{"name": "The Queen", "sort-name": "Queen, The", "locale": "en", "primary": true}]
+		}`))
+	}))
+	defer server.Close()
+
+	c := newTestClient(server)
+	c.SetFormat(FormatJSON)
+
+	artist, err := c.LookupArtist("5441c29d-3602-4898-b1a1-b77fa23b8e50", "tags", "ratings", "aliases")
+	if err != nil {
+		t.Fatalf("LookupArtist: %v", err)
+	}
+
+	if len(artist.Tags) != 1 || artist.Tags[0].Name != "rock" {
+		t.Errorf("Tags = %+v, want [{rock 3}]", artist.Tags)
+	}
+	if artist.Rating == nil || artist.Rating.Value != 4.5 {
+		t.Errorf("Rating = %+v, want &{4.5 13}", artist.Rating)
+	}
+	if len(artist.Aliases) != 1 || artist.Aliases[0].Name != "The Queen" {
+		t.Errorf("Aliases = %+v, want [{The Queen ...}]", artist.Aliases)
+	}
+}
@@ -0,0 +1,132 @@
+/*
+ * Copyright (c) 2014 Michael Wendland
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+ * FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+ * IN THE SOFTWARE.
+ *
+ * 	Authors:
+ * 		Michael Wendland <michael@michiwend.com>
+ */
+
+package gomusicbrainz
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Sentinel errors a WS2Error can be compared against with errors.Is,
+// grouped by the WS2 HTTP status they correspond to.
+var (
+	// ErrBadRequest is returned for HTTP 400 responses, usually caused by
+	// a malformed query or an invalid inc= parameter.
+	ErrBadRequest = errors.New("gomusicbrainz: bad request")
+	// ErrUnauthorized is returned for HTTP 401 responses, e.g. missing or
+	// incorrect Auth credentials on an authenticated endpoint.
+	ErrUnauthorized = errors.New("gomusicbrainz: unauthorized")
+	// ErrNotFound is returned for HTTP 404 responses, e.g. an unknown
+	// MBID.
+	ErrNotFound = errors.New("gomusicbrainz: not found")
+	// ErrRateLimited is returned for HTTP 503 responses that persisted
+	// past getReqeustContext's built-in retries.
+	ErrRateLimited = errors.New("gomusicbrainz: rate limited")
+)
+
+// WS2Error is returned by request methods when the transport fails or the
+// server responds with an error. It never terminates the process the way
+// the old log.Fatalln-based implementation did.
+type WS2Error struct {
+	// Endpoint is the WS2 path that was requested, e.g. "/artist/<mbid>".
+	Endpoint string
+	// StatusCode is the HTTP status of the response, or 0 if the request
+	// never reached the server (see Err).
+	StatusCode int
+	// Message is the <error><text> (or JSON "error") the server sent, if
+	// any.
+	Message string
+	// Err is the underlying transport error (request construction or
+	// network failure), nil when StatusCode is set instead.
+	Err error
+}
+
+func (e *WS2Error) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("gomusicbrainz: %s: %s", e.Endpoint, e.Err)
+	}
+	if e.Message != "" {
+		return fmt.Sprintf("gomusicbrainz: %s: %s (status %d)", e.Endpoint, e.Message, e.StatusCode)
+	}
+	return fmt.Sprintf("gomusicbrainz: %s: unexpected status %d", e.Endpoint, e.StatusCode)
+}
+
+// Unwrap exposes the underlying transport error, if any, to errors.Is/As.
+func (e *WS2Error) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is one of the sentinel errors matching e's
+// StatusCode, enabling errors.Is(err, gomusicbrainz.ErrNotFound) and
+// friends.
+func (e *WS2Error) Is(target error) bool {
+	switch target {
+	case ErrBadRequest:
+		return e.StatusCode == 400
+	case ErrUnauthorized:
+		return e.StatusCode == 401
+	case ErrNotFound:
+		return e.StatusCode == 404
+	case ErrRateLimited:
+		return e.StatusCode == 503
+	}
+	return false
+}
+
+// xmlErrorEnvelope mirrors the WS2 XML error response:
+// <error><text>...</text></error>.
+type xmlErrorEnvelope struct {
+	XMLName xml.Name `xml:"error"`
+	Text    string   `xml:"text"`
+}
+
+// jsonErrorEnvelope mirrors the WS2 JSON error response: {"error": "..."}.
+type jsonErrorEnvelope struct {
+	Error string `json:"error"`
+}
+
+// newWS2StatusError builds a *WS2Error for a non-2xx HTTP response,
+// attempting to parse the server's error envelope out of body for Message.
+func newWS2StatusError(endpoint string, statusCode int, format ResponseFormat, body io.Reader) *WS2Error {
+	werr := &WS2Error{Endpoint: endpoint, StatusCode: statusCode}
+
+	if format == FormatJSON {
+		var env jsonErrorEnvelope
+		if err := json.NewDecoder(body).Decode(&env); err == nil {
+			werr.Message = env.Error
+		}
+		return werr
+	}
+
+	var env xmlErrorEnvelope
+	if err := xml.NewDecoder(body).Decode(&env); err == nil {
+		werr.Message = env.Text
+	}
+	return werr
+}
@@ -0,0 +1,92 @@
+/*
+ * Copyright (c) 2014 Michael Wendland
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+ * FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+ * IN THE SOFTWARE.
+ *
+ * 	Authors:
+ * 		Michael Wendland <michael@michiwend.com>
+ */
+
+package gomusicbrainz
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestWS2ErrorIs(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		target     error
+		want       bool
+	}{
+		{"400 matches ErrBadRequest", 400, ErrBadRequest, true},
+		{"401 matches ErrUnauthorized", 401, ErrUnauthorized, true},
+		{"404 matches ErrNotFound", 404, ErrNotFound, true},
+		{"503 matches ErrRateLimited", 503, ErrRateLimited, true},
+		{"400 does not match ErrNotFound", 400, ErrNotFound, false},
+		{"500 does not match ErrNotFound", 500, ErrNotFound, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := &WS2Error{StatusCode: tt.statusCode}
+			if got := errors.Is(err, tt.target); got != tt.want {
+				t.Errorf("errors.Is(err, target) = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWS2ErrorUnwrap(t *testing.T) {
+	wrapped := errors.New("boom")
+	err := &WS2Error{Endpoint: "/artist/x", Err: wrapped}
+	if !errors.Is(err, wrapped) {
+		t.Errorf("errors.Is(err, wrapped) = false, want true via Unwrap")
+	}
+}
+
+func TestNewWS2StatusErrorXML(t *testing.T) {
+	body := strings.NewReader(`<error><text>Invalid mbid.</text></error>`)
+	err := newWS2StatusError("/artist/bad-mbid", 400, FormatXML, body)
+
+	if err.StatusCode != 400 {
+		t.Errorf("StatusCode = %d, want 400", err.StatusCode)
+	}
+	if err.Message != "Invalid mbid." {
+		t.Errorf("Message = %q, want %q", err.Message, "Invalid mbid.")
+	}
+	if !errors.Is(err, ErrBadRequest) {
+		t.Errorf("errors.Is(err, ErrBadRequest) = false, want true")
+	}
+}
+
+func TestNewWS2StatusErrorJSON(t *testing.T) {
+	body := strings.NewReader(`{"error": "Not Found"}`)
+	err := newWS2StatusError("/artist/bad-mbid", 404, FormatJSON, body)
+
+	if err.Message != "Not Found" {
+		t.Errorf("Message = %q, want %q", err.Message, "Not Found")
+	}
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("errors.Is(err, ErrNotFound) = false, want true")
+	}
+}
@@ -29,11 +29,24 @@ Package gomusicbrainz implements a MusicBrainz WS2 client library.
 package gomusicbrainz
 
 import (
+	"context"
+	"encoding/json"
 	"encoding/xml"
-	"log"
 	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
+	"time"
+)
+
+// ResponseFormat identifies the wire format used to talk to the WS2 server.
+type ResponseFormat int
+
+const (
+	// FormatXML requests/decodes the default XML representation.
+	FormatXML ResponseFormat = iota
+	// FormatJSON requests/decodes the JSON representation (&fmt=json).
+	FormatJSON
 )
 
 // NewWS2Client returns a new instance of WS2Client with default values.
@@ -49,6 +62,10 @@ func NewWS2Client() *WS2Client {
 		"michael@michiwend.com",
 	)
 
+	// MusicBrainz asks public server clients to stay at roughly 1
+	// request/second, see SetRateLimit.
+	c.SetRateLimit(1, 1)
+
 	return &c
 }
 
@@ -56,32 +73,95 @@ func NewWS2Client() *WS2Client {
 type WS2Client struct {
 	WS2RootURL *url.URL // The API root URL
 
+	// Format selects whether requests ask the server for XML (the
+	// default, for backwards compatibility) or JSON responses.
+	Format ResponseFormat
+
+	// Auth authenticates requests to endpoints that require a logged in
+	// MusicBrainz user (user-tags/user-ratings lookups, Submit* and
+	// *Collection methods). It is nil by default.
+	Auth Auth
+
 	userAgentHeader string
-}
+	clientID        string
 
-func (c *WS2Client) getReqeust(data interface{}, params url.Values, endpoint string) error {
+	limiter *tokenBucket
+}
 
-	client := &http.Client{}
+// SetFormat selects the wire format used for subsequent requests. It
+// defaults to FormatXML so existing callers keep working unchanged.
+func (c *WS2Client) SetFormat(format ResponseFormat) {
+	c.Format = format
+}
 
-	req, err := http.NewRequest("GET", c.WS2RootURL.String()+endpoint+"?"+params.Encode(), nil)
-	if err != nil {
-		log.Fatalln(err)
+// rateLimiter lazily initializes the client's token bucket so a zero-value
+// WS2Client (or one built before SetRateLimit existed) still throttles
+// requests at the default 1 req/sec MusicBrainz policy.
+func (c *WS2Client) rateLimiter() *tokenBucket {
+	if c.limiter == nil {
+		c.limiter = newTokenBucket(1, 1)
 	}
+	return c.limiter
+}
 
-	req.Header.Set("User-Agent", c.userAgentHeader)
+// getReqeust performs a GET request without a caller-supplied context. It
+// exists for backwards compatibility with the pre-context API and simply
+// delegates to getReqeustContext using context.Background().
+func (c *WS2Client) getReqeust(data interface{}, params url.Values, endpoint string) error {
+	return c.getReqeustContext(context.Background(), data, params, endpoint)
+}
 
-	resp, err := client.Do(req)
-	if err != nil {
-		log.Fatalln(err)
-	}
-	defer resp.Body.Close()
+func (c *WS2Client) getReqeustContext(ctx context.Context, data interface{}, params url.Values, endpoint string) error {
 
-	decoder := xml.NewDecoder(resp.Body)
+	client := &http.Client{}
 
-	if err = decoder.Decode(data); err != nil {
-		return err
+	if c.Format == FormatJSON {
+		params.Set("fmt", "json")
+	}
+
+	reqURL := c.WS2RootURL.String() + endpoint + "?" + params.Encode()
+
+	for attempt := 0; ; attempt++ {
+		if err := c.rateLimiter().Wait(ctx); err != nil {
+			return err
+		}
+
+		req, err := http.NewRequestWithContext(ctx, "GET", reqURL, nil)
+		if err != nil {
+			return &WS2Error{Endpoint: endpoint, Err: err}
+		}
+		req.Header.Set("User-Agent", c.userAgentHeader)
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return &WS2Error{Endpoint: endpoint, Err: err}
+		}
+
+		if resp.StatusCode == http.StatusServiceUnavailable && attempt < maxRateLimitRetries {
+			retryAfter := parseRetryAfter(resp.Header.Get("Retry-After"))
+			resp.Body.Close()
+
+			t := time.NewTimer(retryAfter)
+			select {
+			case <-ctx.Done():
+				t.Stop()
+				return ctx.Err()
+			case <-t.C:
+			}
+			continue
+		}
+
+		defer resp.Body.Close()
+
+		if resp.StatusCode >= 400 {
+			return newWS2StatusError(endpoint, resp.StatusCode, c.Format, resp.Body)
+		}
+
+		if c.Format == FormatJSON {
+			return json.NewDecoder(resp.Body).Decode(data)
+		}
+		return xml.NewDecoder(resp.Body).Decode(data)
 	}
-	return nil
 }
 
 // intParamToString returns an empty string for -1.
@@ -96,8 +176,11 @@ func intParamToString(i int) string {
 // SetClientInfo sets the HTTP user-agent header of the WS2Client. Please
 // provide meaningful information about your application as described at:
 // https://musicbrainz.org/doc/XML_Web_Service/Rate_Limiting#Provide_meaningful_User-Agent_strings
+// It also derives the client= parameter required by the POST/PUT/DELETE
+// submission endpoints from application and version.
 func (c *WS2Client) SetClientInfo(application string, version string, contact string) {
 	c.userAgentHeader = application + "/" + version + " ( " + contact + " ) "
+	c.clientID = strings.ToLower(strings.ReplaceAll(application, " ", "-")) + "-" + version
 }
 
 // SearchArtist queries MusicBrainz' Search Server for Artists.
@@ -105,18 +188,15 @@ func (c *WS2Client) SetClientInfo(application string, version string, contact st
 // Search Server searches for searchTerm in any of the fields artist, sortname
 // and alias. For a list of all valid search fields visit
 // https://musicbrainz.org/doc/Development/XML_Web_Service/Version_2/Search#Artist
-// limit defines how many entries will be returned by the server (allowed
-// range 1-100, defaults to 25). offset can be used for result pagination. -1
-// can be set for both limit and offset to use the default values.
-func (c *WS2Client) SearchArtist(searchTerm string, limit int, offset int) (*ArtistResponse, error) {
+// page controls pagination: page.Limit caps how many entries the server
+// returns (allowed range 1-100, defaults to 25) and page.Offset shifts the
+// window for subsequent pages. Use NewPageSettings() to defer both to the
+// server's defaults.
+func (c *WS2Client) SearchArtist(searchTerm string, page PageSettings) (*ArtistResponse, error) {
 
 	result := artistResult{}
 	endpoint := "/artist"
-	params := url.Values{
-		"query":  {searchTerm},
-		"limit":  {intParamToString(limit)},
-		"offset": {intParamToString(offset)},
-	}
+	params := searchParams(searchTerm, page)
 
 	if err := c.getReqeust(&result, params, endpoint); err != nil {
 		return nil, err
@@ -130,18 +210,15 @@ func (c *WS2Client) SearchArtist(searchTerm string, limit int, offset int) (*Art
 // Search Server searches the release field only. For a list of all valid
 // search fields visit
 // https://musicbrainz.org/doc/Development/XML_Web_Service/Version_2/Search#Release
-// limit defines how many entries will be returned by the server (allowed
-// range 1-100, defaults to 25). offset can be used for result pagination. -1
-// can be set for both limit and offset to use the default values.
-func (c *WS2Client) SearchRelease(searchTerm string, limit int, offset int) (*ReleaseResponse, error) {
+// page controls pagination: page.Limit caps how many entries the server
+// returns (allowed range 1-100, defaults to 25) and page.Offset shifts the
+// window for subsequent pages. Use NewPageSettings() to defer both to the
+// server's defaults.
+func (c *WS2Client) SearchRelease(searchTerm string, page PageSettings) (*ReleaseResponse, error) {
 
 	result := releaseResult{}
 	endpoint := "/release"
-	params := url.Values{
-		"query":  {searchTerm},
-		"limit":  {intParamToString(limit)},
-		"offset": {intParamToString(offset)},
-	}
+	params := searchParams(searchTerm, page)
 
 	if err := c.getReqeust(&result, params, endpoint); err != nil {
 		return nil, err
@@ -155,18 +232,15 @@ func (c *WS2Client) SearchRelease(searchTerm string, limit int, offset int) (*Re
 // Search Server searches the releasegroup field only. For a list of all valid
 // search fields visit
 // https://musicbrainz.org/doc/Development/XML_Web_Service/Version_2/Search#Release_Group
-// limit defines how many entries will be returned by the server (allowed
-// range 1-100, defaults to 25). offset can be used for result pagination. -1
-// can be set for both limit and offset to use the default values.
-func (c *WS2Client) SearchReleaseGroup(searchTerm string, limit int, offset int) (*ReleaseGroupResponse, error) {
+// page controls pagination: page.Limit caps how many entries the server
+// returns (allowed range 1-100, defaults to 25) and page.Offset shifts the
+// window for subsequent pages. Use NewPageSettings() to defer both to the
+// server's defaults.
+func (c *WS2Client) SearchReleaseGroup(searchTerm string, page PageSettings) (*ReleaseGroupResponse, error) {
 
 	result := releaseGroupResult{}
 	endpoint := "/release-group"
-	params := url.Values{
-		"query":  {searchTerm},
-		"limit":  {intParamToString(limit)},
-		"offset": {intParamToString(offset)},
-	}
+	params := searchParams(searchTerm, page)
 
 	if err := c.getReqeust(&result, params, endpoint); err != nil {
 		return nil, err
@@ -179,18 +253,15 @@ func (c *WS2Client) SearchReleaseGroup(searchTerm string, limit int, offset int)
 // searchTerm follows the Apache Lucene syntax. The Tag index contains only the
 // tag field. For more information visit
 // https://musicbrainz.org/doc/Development/XML_Web_Service/Version_2/Search#Tag
-// limit defines how many entries will be returned by the server (allowed
-// range 1-100, defaults to 25). offset can be used for result pagination. -1
-// can be set for both limit and offset to use the default values.
-func (c *WS2Client) SearchTag(searchTerm string, limit int, offset int) (*TagResponse, error) {
+// page controls pagination: page.Limit caps how many entries the server
+// returns (allowed range 1-100, defaults to 25) and page.Offset shifts the
+// window for subsequent pages. Use NewPageSettings() to defer both to the
+// server's defaults.
+func (c *WS2Client) SearchTag(searchTerm string, page PageSettings) (*TagResponse, error) {
 
 	result := tagResult{}
 	endpoint := "/tag"
-	params := url.Values{
-		"query":  {searchTerm},
-		"limit":  {intParamToString(limit)},
-		"offset": {intParamToString(offset)},
-	}
+	params := searchParams(searchTerm, page)
 
 	if err := c.getReqeust(&result, params, endpoint); err != nil {
 		return nil, err
@@ -198,3 +269,67 @@ func (c *WS2Client) SearchTag(searchTerm string, limit int, offset int) (*TagRes
 
 	return &result.Response, nil
 }
+
+// SearchArtistContext is like SearchArtist but takes a context.Context that can
+// cancel the request, e.g. while the client is blocked on the rate limiter.
+func (c *WS2Client) SearchArtistContext(ctx context.Context, searchTerm string, page PageSettings) (*ArtistResponse, error) {
+
+	result := artistResult{}
+	endpoint := "/artist"
+	params := searchParams(searchTerm, page)
+
+	if err := c.getReqeustContext(ctx, &result, params, endpoint); err != nil {
+		return nil, err
+	}
+
+	return &result.Resonse, nil
+
+}
+
+// SearchReleaseContext is like SearchRelease but takes a context.Context that can
+// cancel the request, e.g. while the client is blocked on the rate limiter.
+func (c *WS2Client) SearchReleaseContext(ctx context.Context, searchTerm string, page PageSettings) (*ReleaseResponse, error) {
+
+	result := releaseResult{}
+	endpoint := "/release"
+	params := searchParams(searchTerm, page)
+
+	if err := c.getReqeustContext(ctx, &result, params, endpoint); err != nil {
+		return nil, err
+	}
+
+	return &result.Response, nil
+
+}
+
+// SearchReleaseGroupContext is like SearchReleaseGroup but takes a context.Context that can
+// cancel the request, e.g. while the client is blocked on the rate limiter.
+func (c *WS2Client) SearchReleaseGroupContext(ctx context.Context, searchTerm string, page PageSettings) (*ReleaseGroupResponse, error) {
+
+	result := releaseGroupResult{}
+	endpoint := "/release-group"
+	params := searchParams(searchTerm, page)
+
+	if err := c.getReqeustContext(ctx, &result, params, endpoint); err != nil {
+		return nil, err
+	}
+
+	return &result.Response, nil
+
+}
+
+// SearchTagContext is like SearchTag but takes a context.Context that can
+// cancel the request, e.g. while the client is blocked on the rate limiter.
+func (c *WS2Client) SearchTagContext(ctx context.Context, searchTerm string, page PageSettings) (*TagResponse, error) {
+
+	result := tagResult{}
+	endpoint := "/tag"
+	params := searchParams(searchTerm, page)
+
+	if err := c.getReqeustContext(ctx, &result, params, endpoint); err != nil {
+		return nil, err
+	}
+
+	return &result.Response, nil
+
+}
@@ -0,0 +1,100 @@
+/*
+ * Copyright (c) 2014 Michael Wendland
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+ * FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+ * IN THE SOFTWARE.
+ *
+ * 	Authors:
+ * 		Michael Wendland <michael@michiwend.com>
+ */
+
+package gomusicbrainz
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+// newTestClient points a WS2Client at server and disables rate limiting so
+// tests run instantly.
+func newTestClient(server *httptest.Server) *WS2Client {
+	c := NewWS2Client()
+	c.WS2RootURL, _ = url.Parse(server.URL)
+	c.SetRateLimit(1000, 1000)
+	return c
+}
+
+// TestSearchArtistJSON proves the JSON decode path end to end: MusicBrainz's
+// JSON search responses put count/offset/artists at the top level, with no
+// <metadata> envelope the way XML has, so a wrapper field without a
+// matching json tag would silently decode to a zero value.
+func TestSearchArtistJSON(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{
+			"count": 1,
+			"offset": 0,
+			"artists": [
+				{"id": "5441c29d-3602-4898-b1a1-b77fa23b8e50", "name": "Queen", "sort-name": "Queen"}
+			]
+		}`))
+	}))
+	defer server.Close()
+
+	c := newTestClient(server)
+	c.SetFormat(FormatJSON)
+
+	resp, err := c.SearchArtist("Queen", NewPageSettings())
+	if err != nil {
+		t.Fatalf("SearchArtist: %v", err)
+	}
+
+	if resp.Count != 1 {
+		t.Errorf("Count = %d, want 1", resp.Count)
+	}
+	if len(resp.Artists) != 1 {
+		t.Fatalf("len(Artists) = %d, want 1", len(resp.Artists))
+	}
+	if got, want := resp.Artists[0].Name, "Queen"; got != want {
+		t.Errorf("Artists[0].Name = %q, want %q", got, want)
+	}
+}
+
+// TestLookupArtistJSON proves the single-entity lookup path decodes a flat
+// JSON object (no "artist" wrapping key) the same way the real WS2 JSON
+// format does.
+func TestLookupArtistJSON(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id": "5441c29d-3602-4898-b1a1-b77fa23b8e50", "name": "Queen", "sort-name": "Queen"}`))
+	}))
+	defer server.Close()
+
+	c := newTestClient(server)
+	c.SetFormat(FormatJSON)
+
+	artist, err := c.LookupArtist("5441c29d-3602-4898-b1a1-b77fa23b8e50")
+	if err != nil {
+		t.Fatalf("LookupArtist: %v", err)
+	}
+	if got, want := artist.Name, "Queen"; got != want {
+		t.Errorf("artist.Name = %q, want %q", got, want)
+	}
+}
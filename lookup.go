@@ -0,0 +1,483 @@
+/*
+ * Copyright (c) 2014 Michael Wendland
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+ * FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+ * IN THE SOFTWARE.
+ *
+ * 	Authors:
+ * 		Michael Wendland <michael@michiwend.com>
+ */
+
+package gomusicbrainz
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"strings"
+)
+
+// incParams builds the "inc=" query parameter from a list of subqueries,
+// joined with "+" as required by the WS2 spec.
+func incParams(inc []string) url.Values {
+	params := url.Values{}
+	if len(inc) > 0 {
+		params.Set("inc", strings.Join(inc, "+"))
+	}
+	return params
+}
+
+// The single-entity lookup results below are named (rather than anonymous
+// structs) so each can implement UnmarshalJSON: MusicBrainz's JSON lookup
+// responses put the entity's fields directly at the top level, with no
+// wrapping object the way the XML <metadata> envelope requires.
+
+type artistLookupResult struct {
+	Artist Artist `xml:"artist" json:"artist"`
+}
+
+func (r *artistLookupResult) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, &r.Artist)
+}
+
+type releaseLookupResult struct {
+	Release Release `xml:"release" json:"release"`
+}
+
+func (r *releaseLookupResult) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, &r.Release)
+}
+
+type releaseGroupLookupResult struct {
+	ReleaseGroup ReleaseGroup `xml:"release-group" json:"release-group"`
+}
+
+func (r *releaseGroupLookupResult) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, &r.ReleaseGroup)
+}
+
+type recordingLookupResult struct {
+	Recording Recording `xml:"recording" json:"recording"`
+}
+
+func (r *recordingLookupResult) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, &r.Recording)
+}
+
+type workLookupResult struct {
+	Work Work `xml:"work" json:"work"`
+}
+
+func (r *workLookupResult) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, &r.Work)
+}
+
+type labelLookupResult struct {
+	Label Label `xml:"label" json:"label"`
+}
+
+func (r *labelLookupResult) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, &r.Label)
+}
+
+type areaLookupResult struct {
+	Area Area `xml:"area" json:"area"`
+}
+
+func (r *areaLookupResult) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, &r.Area)
+}
+
+type eventLookupResult struct {
+	Event Event `xml:"event" json:"event"`
+}
+
+func (r *eventLookupResult) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, &r.Event)
+}
+
+type instrumentLookupResult struct {
+	Instrument Instrument `xml:"instrument" json:"instrument"`
+}
+
+func (r *instrumentLookupResult) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, &r.Instrument)
+}
+
+type seriesLookupResult struct {
+	Series Series `xml:"series" json:"series"`
+}
+
+func (r *seriesLookupResult) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, &r.Series)
+}
+
+type urlLookupResult struct {
+	URL URL `xml:"url" json:"url"`
+}
+
+func (r *urlLookupResult) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, &r.URL)
+}
+
+// LookupArtist performs a lookup request for an Artist with a known MBID.
+// inc can be used to request subqueries such as "tags", "ratings" and
+// "aliases", which populate Artist.Tags/Rating/Aliases. Other subqueries
+// (e.g. "recordings", "release-groups", "artist-rels") are accepted and
+// sent to the server, but their response data isn't modeled on Artist yet
+// and is silently dropped by the decoder.
+func (c *WS2Client) LookupArtist(mbid string, inc ...string) (*Artist, error) {
+	result := artistLookupResult{}
+	endpoint := "/artist/" + mbid
+
+	if err := c.getReqeust(&result, incParams(inc), endpoint); err != nil {
+		return nil, err
+	}
+	return &result.Artist, nil
+}
+
+// LookupRelease performs a lookup request for a Release with a known MBID.
+func (c *WS2Client) LookupRelease(mbid string, inc ...string) (*Release, error) {
+	result := releaseLookupResult{}
+	endpoint := "/release/" + mbid
+
+	if err := c.getReqeust(&result, incParams(inc), endpoint); err != nil {
+		return nil, err
+	}
+	return &result.Release, nil
+}
+
+// LookupReleaseGroup performs a lookup request for a ReleaseGroup with a
+// known MBID.
+func (c *WS2Client) LookupReleaseGroup(mbid string, inc ...string) (*ReleaseGroup, error) {
+	result := releaseGroupLookupResult{}
+	endpoint := "/release-group/" + mbid
+
+	if err := c.getReqeust(&result, incParams(inc), endpoint); err != nil {
+		return nil, err
+	}
+	return &result.ReleaseGroup, nil
+}
+
+// LookupRecording performs a lookup request for a Recording with a known
+// MBID.
+func (c *WS2Client) LookupRecording(mbid string, inc ...string) (*Recording, error) {
+	result := recordingLookupResult{}
+	endpoint := "/recording/" + mbid
+
+	if err := c.getReqeust(&result, incParams(inc), endpoint); err != nil {
+		return nil, err
+	}
+	return &result.Recording, nil
+}
+
+// LookupWork performs a lookup request for a Work with a known MBID.
+func (c *WS2Client) LookupWork(mbid string, inc ...string) (*Work, error) {
+	result := workLookupResult{}
+	endpoint := "/work/" + mbid
+
+	if err := c.getReqeust(&result, incParams(inc), endpoint); err != nil {
+		return nil, err
+	}
+	return &result.Work, nil
+}
+
+// LookupLabel performs a lookup request for a Label with a known MBID.
+func (c *WS2Client) LookupLabel(mbid string, inc ...string) (*Label, error) {
+	result := labelLookupResult{}
+	endpoint := "/label/" + mbid
+
+	if err := c.getReqeust(&result, incParams(inc), endpoint); err != nil {
+		return nil, err
+	}
+	return &result.Label, nil
+}
+
+// LookupArea performs a lookup request for an Area with a known MBID.
+func (c *WS2Client) LookupArea(mbid string, inc ...string) (*Area, error) {
+	result := areaLookupResult{}
+	endpoint := "/area/" + mbid
+
+	if err := c.getReqeust(&result, incParams(inc), endpoint); err != nil {
+		return nil, err
+	}
+	return &result.Area, nil
+}
+
+// LookupEvent performs a lookup request for an Event with a known MBID.
+func (c *WS2Client) LookupEvent(mbid string, inc ...string) (*Event, error) {
+	result := eventLookupResult{}
+	endpoint := "/event/" + mbid
+
+	if err := c.getReqeust(&result, incParams(inc), endpoint); err != nil {
+		return nil, err
+	}
+	return &result.Event, nil
+}
+
+// LookupInstrument performs a lookup request for an Instrument with a known
+// MBID.
+func (c *WS2Client) LookupInstrument(mbid string, inc ...string) (*Instrument, error) {
+	result := instrumentLookupResult{}
+	endpoint := "/instrument/" + mbid
+
+	if err := c.getReqeust(&result, incParams(inc), endpoint); err != nil {
+		return nil, err
+	}
+	return &result.Instrument, nil
+}
+
+// LookupSeries performs a lookup request for a Series with a known MBID.
+func (c *WS2Client) LookupSeries(mbid string, inc ...string) (*Series, error) {
+	result := seriesLookupResult{}
+	endpoint := "/series/" + mbid
+
+	if err := c.getReqeust(&result, incParams(inc), endpoint); err != nil {
+		return nil, err
+	}
+	return &result.Series, nil
+}
+
+// LookupURL performs a lookup request for a URL resource with a known MBID.
+func (c *WS2Client) LookupURL(mbid string, inc ...string) (*URL, error) {
+	result := urlLookupResult{}
+	endpoint := "/url/" + mbid
+
+	if err := c.getReqeust(&result, incParams(inc), endpoint); err != nil {
+		return nil, err
+	}
+	return &result.URL, nil
+}
+
+// LookupDiscID performs a lookup request for the Releases associated with a
+// CD Table of Contents discid, as found on https://musicbrainz.org/doc/Disc_ID_Calculation.
+func (c *WS2Client) LookupDiscID(discid string, inc ...string) (*ReleaseResponse, error) {
+	result := releaseResult{}
+	endpoint := "/discid/" + discid
+
+	if err := c.getReqeust(&result, incParams(inc), endpoint); err != nil {
+		return nil, err
+	}
+	return &result.Response, nil
+}
+
+// LookupISRC performs a lookup request for the Recordings associated with an
+// International Standard Recording Code.
+func (c *WS2Client) LookupISRC(isrc string, inc ...string) (*RecordingResponse, error) {
+	result := recordingResult{}
+	endpoint := "/isrc/" + isrc
+
+	if err := c.getReqeust(&result, incParams(inc), endpoint); err != nil {
+		return nil, err
+	}
+	return &result.Response, nil
+}
+
+// LookupISWC performs a lookup request for the Works associated with an
+// International Standard Musical Work Code.
+func (c *WS2Client) LookupISWC(iswc string, inc ...string) (*WorkResponse, error) {
+	result := workResult{}
+	endpoint := "/iswc/" + iswc
+
+	if err := c.getReqeust(&result, incParams(inc), endpoint); err != nil {
+		return nil, err
+	}
+	return &result.Response, nil
+}
+
+// LookupArtistContext is like LookupArtist but takes a context.Context that can
+// cancel the request, e.g. while the client is blocked on the rate limiter.
+func (c *WS2Client) LookupArtistContext(ctx context.Context, mbid string, inc ...string) (*Artist, error) {
+	result := artistLookupResult{}
+	endpoint := "/artist/" + mbid
+
+	if err := c.getReqeustContext(ctx, &result, incParams(inc), endpoint); err != nil {
+		return nil, err
+	}
+	return &result.Artist, nil
+
+}
+
+// LookupReleaseContext is like LookupRelease but takes a context.Context that can
+// cancel the request, e.g. while the client is blocked on the rate limiter.
+func (c *WS2Client) LookupReleaseContext(ctx context.Context, mbid string, inc ...string) (*Release, error) {
+	result := releaseLookupResult{}
+	endpoint := "/release/" + mbid
+
+	if err := c.getReqeustContext(ctx, &result, incParams(inc), endpoint); err != nil {
+		return nil, err
+	}
+	return &result.Release, nil
+
+}
+
+// LookupReleaseGroupContext is like LookupReleaseGroup but takes a context.Context that can
+// cancel the request, e.g. while the client is blocked on the rate limiter.
+func (c *WS2Client) LookupReleaseGroupContext(ctx context.Context, mbid string, inc ...string) (*ReleaseGroup, error) {
+	result := releaseGroupLookupResult{}
+	endpoint := "/release-group/" + mbid
+
+	if err := c.getReqeustContext(ctx, &result, incParams(inc), endpoint); err != nil {
+		return nil, err
+	}
+	return &result.ReleaseGroup, nil
+
+}
+
+// LookupRecordingContext is like LookupRecording but takes a context.Context that can
+// cancel the request, e.g. while the client is blocked on the rate limiter.
+func (c *WS2Client) LookupRecordingContext(ctx context.Context, mbid string, inc ...string) (*Recording, error) {
+	result := recordingLookupResult{}
+	endpoint := "/recording/" + mbid
+
+	if err := c.getReqeustContext(ctx, &result, incParams(inc), endpoint); err != nil {
+		return nil, err
+	}
+	return &result.Recording, nil
+
+}
+
+// LookupWorkContext is like LookupWork but takes a context.Context that can
+// cancel the request, e.g. while the client is blocked on the rate limiter.
+func (c *WS2Client) LookupWorkContext(ctx context.Context, mbid string, inc ...string) (*Work, error) {
+	result := workLookupResult{}
+	endpoint := "/work/" + mbid
+
+	if err := c.getReqeustContext(ctx, &result, incParams(inc), endpoint); err != nil {
+		return nil, err
+	}
+	return &result.Work, nil
+
+}
+
+// LookupLabelContext is like LookupLabel but takes a context.Context that can
+// cancel the request, e.g. while the client is blocked on the rate limiter.
+func (c *WS2Client) LookupLabelContext(ctx context.Context, mbid string, inc ...string) (*Label, error) {
+	result := labelLookupResult{}
+	endpoint := "/label/" + mbid
+
+	if err := c.getReqeustContext(ctx, &result, incParams(inc), endpoint); err != nil {
+		return nil, err
+	}
+	return &result.Label, nil
+
+}
+
+// LookupAreaContext is like LookupArea but takes a context.Context that can
+// cancel the request, e.g. while the client is blocked on the rate limiter.
+func (c *WS2Client) LookupAreaContext(ctx context.Context, mbid string, inc ...string) (*Area, error) {
+	result := areaLookupResult{}
+	endpoint := "/area/" + mbid
+
+	if err := c.getReqeustContext(ctx, &result, incParams(inc), endpoint); err != nil {
+		return nil, err
+	}
+	return &result.Area, nil
+
+}
+
+// LookupEventContext is like LookupEvent but takes a context.Context that can
+// cancel the request, e.g. while the client is blocked on the rate limiter.
+func (c *WS2Client) LookupEventContext(ctx context.Context, mbid string, inc ...string) (*Event, error) {
+	result := eventLookupResult{}
+	endpoint := "/event/" + mbid
+
+	if err := c.getReqeustContext(ctx, &result, incParams(inc), endpoint); err != nil {
+		return nil, err
+	}
+	return &result.Event, nil
+
+}
+
+// LookupInstrumentContext is like LookupInstrument but takes a context.Context that can
+// cancel the request, e.g. while the client is blocked on the rate limiter.
+func (c *WS2Client) LookupInstrumentContext(ctx context.Context, mbid string, inc ...string) (*Instrument, error) {
+	result := instrumentLookupResult{}
+	endpoint := "/instrument/" + mbid
+
+	if err := c.getReqeustContext(ctx, &result, incParams(inc), endpoint); err != nil {
+		return nil, err
+	}
+	return &result.Instrument, nil
+
+}
+
+// LookupSeriesContext is like LookupSeries but takes a context.Context that can
+// cancel the request, e.g. while the client is blocked on the rate limiter.
+func (c *WS2Client) LookupSeriesContext(ctx context.Context, mbid string, inc ...string) (*Series, error) {
+	result := seriesLookupResult{}
+	endpoint := "/series/" + mbid
+
+	if err := c.getReqeustContext(ctx, &result, incParams(inc), endpoint); err != nil {
+		return nil, err
+	}
+	return &result.Series, nil
+
+}
+
+// LookupURLContext is like LookupURL but takes a context.Context that can
+// cancel the request, e.g. while the client is blocked on the rate limiter.
+func (c *WS2Client) LookupURLContext(ctx context.Context, mbid string, inc ...string) (*URL, error) {
+	result := urlLookupResult{}
+	endpoint := "/url/" + mbid
+
+	if err := c.getReqeustContext(ctx, &result, incParams(inc), endpoint); err != nil {
+		return nil, err
+	}
+	return &result.URL, nil
+
+}
+
+// LookupDiscIDContext is like LookupDiscID but takes a context.Context that can
+// cancel the request, e.g. while the client is blocked on the rate limiter.
+func (c *WS2Client) LookupDiscIDContext(ctx context.Context, discid string, inc ...string) (*ReleaseResponse, error) {
+	result := releaseResult{}
+	endpoint := "/discid/" + discid
+
+	if err := c.getReqeustContext(ctx, &result, incParams(inc), endpoint); err != nil {
+		return nil, err
+	}
+	return &result.Response, nil
+
+}
+
+// LookupISRCContext is like LookupISRC but takes a context.Context that can
+// cancel the request, e.g. while the client is blocked on the rate limiter.
+func (c *WS2Client) LookupISRCContext(ctx context.Context, isrc string, inc ...string) (*RecordingResponse, error) {
+	result := recordingResult{}
+	endpoint := "/isrc/" + isrc
+
+	if err := c.getReqeustContext(ctx, &result, incParams(inc), endpoint); err != nil {
+		return nil, err
+	}
+	return &result.Response, nil
+
+}
+
+// LookupISWCContext is like LookupISWC but takes a context.Context that can
+// cancel the request, e.g. while the client is blocked on the rate limiter.
+func (c *WS2Client) LookupISWCContext(ctx context.Context, iswc string, inc ...string) (*WorkResponse, error) {
+	result := workResult{}
+	endpoint := "/iswc/" + iswc
+
+	if err := c.getReqeustContext(ctx, &result, incParams(inc), endpoint); err != nil {
+		return nil, err
+	}
+	return &result.Response, nil
+
+}
@@ -0,0 +1,139 @@
+/*
+ * Copyright (c) 2014 Michael Wendland
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+ * FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+ * IN THE SOFTWARE.
+ *
+ * 	Authors:
+ * 		Michael Wendland <michael@michiwend.com>
+ */
+
+package gomusicbrainz
+
+import "context"
+
+// maxSearchLimit is the highest limit value the WS2 Search Server accepts
+// per page, used by WithMaxLimit.
+const maxSearchLimit = 100
+
+// PageSettings controls pagination for Search and Browse requests. Use
+// NewPageSettings to build one that defers Limit and Offset to the server's
+// own defaults (currently limit=25, offset=0); the Go zero value of
+// PageSettings instead requests limit=0/offset=0 literally.
+type PageSettings struct {
+	Limit  int
+	Offset int
+}
+
+// NewPageSettings returns a PageSettings with both Limit and Offset set to
+// use the server's default values.
+func NewPageSettings() PageSettings {
+	return PageSettings{Limit: -1, Offset: -1}
+}
+
+// WithMaxLimit returns a copy of p with Limit set to the maximum page size
+// the Search Server accepts (100).
+func (p PageSettings) WithMaxLimit() PageSettings {
+	p.Limit = maxSearchLimit
+	return p
+}
+
+// WithOffset returns a copy of p with Offset set to n.
+func (p PageSettings) WithOffset(n int) PageSettings {
+	p.Offset = n
+	return p
+}
+
+// EachArtist walks every page of an Artist search, calling fn once per
+// result. It stops when fn returns false, when the server has returned
+// every result (offset+count == total), or when an error occurs, in which
+// case the error is returned to the caller.
+func (c *WS2Client) EachArtist(searchTerm string, fn func(*Artist) bool) error {
+	return c.EachArtistContext(context.Background(), searchTerm, fn)
+}
+
+// EachArtistContext is like EachArtist but takes a context.Context that can
+// cancel the underlying requests, e.g. while blocked on the rate limiter.
+func (c *WS2Client) EachArtistContext(ctx context.Context, searchTerm string, fn func(*Artist) bool) error {
+	page := NewPageSettings().WithMaxLimit()
+
+	for {
+		resp, err := c.SearchArtistContext(ctx, searchTerm, page)
+		if err != nil {
+			return err
+		}
+
+		if len(resp.Artists) == 0 {
+			// A page with no results but offset < count would otherwise
+			// leave offset unchanged, re-requesting the same page forever.
+			return nil
+		}
+
+		for i := range resp.Artists {
+			if !fn(&resp.Artists[i]) {
+				return nil
+			}
+		}
+
+		offset := resp.Offset + len(resp.Artists)
+		if offset >= resp.Count {
+			return nil
+		}
+		page = page.WithOffset(offset)
+	}
+}
+
+// EachRelease walks every page of a Release search, calling fn once per
+// result. It stops when fn returns false, when the server has returned
+// every result (offset+count == total), or when an error occurs, in which
+// case the error is returned to the caller.
+func (c *WS2Client) EachRelease(searchTerm string, fn func(*Release) bool) error {
+	return c.EachReleaseContext(context.Background(), searchTerm, fn)
+}
+
+// EachReleaseContext is like EachRelease but takes a context.Context that
+// can cancel the underlying requests, e.g. while blocked on the rate
+// limiter.
+func (c *WS2Client) EachReleaseContext(ctx context.Context, searchTerm string, fn func(*Release) bool) error {
+	page := NewPageSettings().WithMaxLimit()
+
+	for {
+		resp, err := c.SearchReleaseContext(ctx, searchTerm, page)
+		if err != nil {
+			return err
+		}
+
+		if len(resp.Releases) == 0 {
+			// A page with no results but offset < count would otherwise
+			// leave offset unchanged, re-requesting the same page forever.
+			return nil
+		}
+
+		for i := range resp.Releases {
+			if !fn(&resp.Releases[i]) {
+				return nil
+			}
+		}
+
+		offset := resp.Offset + len(resp.Releases)
+		if offset >= resp.Count {
+			return nil
+		}
+		page = page.WithOffset(offset)
+	}
+}
@@ -0,0 +1,69 @@
+/*
+ * Copyright (c) 2014 Michael Wendland
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+ * FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+ * IN THE SOFTWARE.
+ *
+ * 	Authors:
+ * 		Michael Wendland <michael@michiwend.com>
+ */
+
+package gomusicbrainz
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestEachArtistStopsOnEmptyPage proves EachArtist terminates instead of
+// looping forever when the server reports more results (Count) than it
+// actually returns on a page (e.g. a stale/inconsistent Count), which would
+// otherwise leave offset stuck.
+func TestEachArtistStopsOnEmptyPage(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		if requests == 1 {
+			w.Write([]byte(`{"count": 5, "offset": 0, "artists": [{"id": "1", "name": "A", "sort-name": "A"}]}`))
+			return
+		}
+		// Every subsequent page comes back empty despite offset < count.
+		w.Write([]byte(`{"count": 5, "offset": 1, "artists": []}`))
+	}))
+	defer server.Close()
+
+	c := newTestClient(server)
+	c.SetFormat(FormatJSON)
+
+	var names []string
+	err := c.EachArtist("A", func(a *Artist) bool {
+		names = append(names, a.Name)
+		return true
+	})
+	if err != nil {
+		t.Fatalf("EachArtist: %v", err)
+	}
+	if len(names) != 1 {
+		t.Errorf("got %d artists, want 1", len(names))
+	}
+	if requests != 2 {
+		t.Errorf("got %d requests, want 2 (one with results, one empty that should stop the loop)", requests)
+	}
+}
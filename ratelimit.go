@@ -0,0 +1,130 @@
+/*
+ * Copyright (c) 2014 Michael Wendland
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+ * FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+ * IN THE SOFTWARE.
+ *
+ * 	Authors:
+ * 		Michael Wendland <michael@michiwend.com>
+ */
+
+package gomusicbrainz
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// maxRateLimitRetries caps the number of transparent retries getReqeustContext
+// performs after receiving a 503 from the server before giving up and
+// returning an error to the caller.
+const maxRateLimitRetries = 3
+
+// defaultRetryAfter is used when a 503 response carries no (or an
+// unparsable) Retry-After header.
+const defaultRetryAfter = 1 * time.Second
+
+// SetRateLimit configures the client's request throttle. rps is the number
+// of requests allowed per second (sustained rate), burst is the number of
+// requests that may fire back-to-back before throttling kicks in. The
+// MusicBrainz documentation asks public server clients to stay at roughly 1
+// req/sec, which is also what NewWS2Client configures by default.
+func (c *WS2Client) SetRateLimit(rps float64, burst int) {
+	c.limiter = newTokenBucket(rps, burst)
+}
+
+// tokenBucket is a minimal token-bucket rate limiter. It refills at a fixed
+// rate up to a maximum burst size and blocks callers in Wait until a token
+// is available or the context is cancelled.
+type tokenBucket struct {
+	mu         sync.Mutex
+	rate       float64 // tokens added per second
+	burst      float64 // maximum number of tokens
+	tokens     float64
+	lastRefill time.Time
+}
+
+func newTokenBucket(rps float64, burst int) *tokenBucket {
+	if burst < 1 {
+		burst = 1
+	}
+	return &tokenBucket{
+		rate:       rps,
+		burst:      float64(burst),
+		tokens:     float64(burst),
+		lastRefill: time.Now(),
+	}
+}
+
+// Wait blocks until a token is available, or returns ctx.Err() if ctx is
+// cancelled first.
+func (b *tokenBucket) Wait(ctx context.Context) error {
+	for {
+		d := b.reserve()
+		if d <= 0 {
+			return nil
+		}
+
+		t := time.NewTimer(d)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return ctx.Err()
+		case <-t.C:
+		}
+	}
+}
+
+// reserve refills the bucket based on elapsed time and, if a token is
+// available, consumes it and returns 0. Otherwise it returns the duration
+// the caller should wait before trying again.
+func (b *tokenBucket) reserve() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	elapsed := now.Sub(b.lastRefill).Seconds()
+	b.tokens += elapsed * b.rate
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+	b.lastRefill = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return 0
+	}
+
+	missing := 1 - b.tokens
+	return time.Duration(missing/b.rate*float64(time.Second)) + time.Millisecond
+}
+
+// parseRetryAfter parses the Retry-After header sent by the server on a 503
+// response (https://musicbrainz.org/doc/MusicBrainz_API/Rate_Limiting). It
+// falls back to defaultRetryAfter if the header is missing or malformed.
+func parseRetryAfter(header string) time.Duration {
+	if header == "" {
+		return defaultRetryAfter
+	}
+	if secs, err := strconv.Atoi(header); err == nil {
+		return time.Duration(secs) * time.Second
+	}
+	return defaultRetryAfter
+}
@@ -0,0 +1,330 @@
+/*
+ * Copyright (c) 2014 Michael Wendland
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+ * FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+ * IN THE SOFTWARE.
+ *
+ * 	Authors:
+ * 		Michael Wendland <michael@michiwend.com>
+ */
+
+package gomusicbrainz
+
+import (
+	"context"
+	"net/url"
+)
+
+// searchParams builds the query parameters shared by every search request.
+func searchParams(searchTerm string, page PageSettings) url.Values {
+	return url.Values{
+		"query":  {searchTerm},
+		"limit":  {intParamToString(page.Limit)},
+		"offset": {intParamToString(page.Offset)},
+	}
+}
+
+// SearchRecording queries MusicBrainz' Search Server for Recordings.
+// searchTerm follows the Apache Lucene syntax. For a list of all valid
+// search fields visit
+// https://musicbrainz.org/doc/Development/XML_Web_Service/Version_2/Search#Recording
+// page controls pagination: page.Limit caps how many entries the server
+// returns (allowed range 1-100, defaults to 25) and page.Offset shifts the
+// window for subsequent pages. Use NewPageSettings() to defer both to the
+// server's defaults.
+func (c *WS2Client) SearchRecording(searchTerm string, page PageSettings) (*RecordingResponse, error) {
+
+	result := recordingResult{}
+	if err := c.getReqeust(&result, searchParams(searchTerm, page), "/recording"); err != nil {
+		return nil, err
+	}
+	return &result.Response, nil
+}
+
+// SearchWork queries MusicBrainz' Search Server for Works.
+// searchTerm follows the Apache Lucene syntax. For a list of all valid
+// search fields visit
+// https://musicbrainz.org/doc/Development/XML_Web_Service/Version_2/Search#Work
+// page controls pagination: page.Limit caps how many entries the server
+// returns (allowed range 1-100, defaults to 25) and page.Offset shifts the
+// window for subsequent pages. Use NewPageSettings() to defer both to the
+// server's defaults.
+func (c *WS2Client) SearchWork(searchTerm string, page PageSettings) (*WorkResponse, error) {
+
+	result := workResult{}
+	if err := c.getReqeust(&result, searchParams(searchTerm, page), "/work"); err != nil {
+		return nil, err
+	}
+	return &result.Response, nil
+}
+
+// SearchLabel queries MusicBrainz' Search Server for Labels.
+// searchTerm follows the Apache Lucene syntax. For a list of all valid
+// search fields visit
+// https://musicbrainz.org/doc/Development/XML_Web_Service/Version_2/Search#Label
+// page controls pagination: page.Limit caps how many entries the server
+// returns (allowed range 1-100, defaults to 25) and page.Offset shifts the
+// window for subsequent pages. Use NewPageSettings() to defer both to the
+// server's defaults.
+func (c *WS2Client) SearchLabel(searchTerm string, page PageSettings) (*LabelResponse, error) {
+
+	result := labelResult{}
+	if err := c.getReqeust(&result, searchParams(searchTerm, page), "/label"); err != nil {
+		return nil, err
+	}
+	return &result.Response, nil
+}
+
+// SearchArea queries MusicBrainz' Search Server for Areas.
+// searchTerm follows the Apache Lucene syntax. For a list of all valid
+// search fields visit
+// https://musicbrainz.org/doc/Development/XML_Web_Service/Version_2/Search#Area
+// page controls pagination: page.Limit caps how many entries the server
+// returns (allowed range 1-100, defaults to 25) and page.Offset shifts the
+// window for subsequent pages. Use NewPageSettings() to defer both to the
+// server's defaults.
+func (c *WS2Client) SearchArea(searchTerm string, page PageSettings) (*AreaResponse, error) {
+
+	result := areaResult{}
+	if err := c.getReqeust(&result, searchParams(searchTerm, page), "/area"); err != nil {
+		return nil, err
+	}
+	return &result.Response, nil
+}
+
+// SearchEvent queries MusicBrainz' Search Server for Events.
+// searchTerm follows the Apache Lucene syntax. For a list of all valid
+// search fields visit
+// https://musicbrainz.org/doc/Development/XML_Web_Service/Version_2/Search#Event
+// page controls pagination: page.Limit caps how many entries the server
+// returns (allowed range 1-100, defaults to 25) and page.Offset shifts the
+// window for subsequent pages. Use NewPageSettings() to defer both to the
+// server's defaults.
+func (c *WS2Client) SearchEvent(searchTerm string, page PageSettings) (*EventResponse, error) {
+
+	result := eventResult{}
+	if err := c.getReqeust(&result, searchParams(searchTerm, page), "/event"); err != nil {
+		return nil, err
+	}
+	return &result.Response, nil
+}
+
+// SearchInstrument queries MusicBrainz' Search Server for Instruments.
+// searchTerm follows the Apache Lucene syntax. For a list of all valid
+// search fields visit
+// https://musicbrainz.org/doc/Development/XML_Web_Service/Version_2/Search#Instrument
+// page controls pagination: page.Limit caps how many entries the server
+// returns (allowed range 1-100, defaults to 25) and page.Offset shifts the
+// window for subsequent pages. Use NewPageSettings() to defer both to the
+// server's defaults.
+func (c *WS2Client) SearchInstrument(searchTerm string, page PageSettings) (*InstrumentResponse, error) {
+
+	result := instrumentResult{}
+	if err := c.getReqeust(&result, searchParams(searchTerm, page), "/instrument"); err != nil {
+		return nil, err
+	}
+	return &result.Response, nil
+}
+
+// SearchSeries queries MusicBrainz' Search Server for Series.
+// searchTerm follows the Apache Lucene syntax. For a list of all valid
+// search fields visit
+// https://musicbrainz.org/doc/Development/XML_Web_Service/Version_2/Search#Series
+// page controls pagination: page.Limit caps how many entries the server
+// returns (allowed range 1-100, defaults to 25) and page.Offset shifts the
+// window for subsequent pages. Use NewPageSettings() to defer both to the
+// server's defaults.
+func (c *WS2Client) SearchSeries(searchTerm string, page PageSettings) (*SeriesResponse, error) {
+
+	result := seriesResult{}
+	if err := c.getReqeust(&result, searchParams(searchTerm, page), "/series"); err != nil {
+		return nil, err
+	}
+	return &result.Response, nil
+}
+
+// SearchURL queries MusicBrainz' Search Server for URLs.
+// searchTerm follows the Apache Lucene syntax. For a list of all valid
+// search fields visit
+// https://musicbrainz.org/doc/Development/XML_Web_Service/Version_2/Search#URL
+// page controls pagination: page.Limit caps how many entries the server
+// returns (allowed range 1-100, defaults to 25) and page.Offset shifts the
+// window for subsequent pages. Use NewPageSettings() to defer both to the
+// server's defaults.
+func (c *WS2Client) SearchURL(searchTerm string, page PageSettings) (*URLResponse, error) {
+
+	result := urlResult{}
+	if err := c.getReqeust(&result, searchParams(searchTerm, page), "/url"); err != nil {
+		return nil, err
+	}
+	return &result.Response, nil
+}
+
+// SearchAnnotation queries MusicBrainz' Search Server for Annotations.
+// searchTerm follows the Apache Lucene syntax. For a list of all valid
+// search fields visit
+// https://musicbrainz.org/doc/Development/XML_Web_Service/Version_2/Search#Annotation
+// page controls pagination: page.Limit caps how many entries the server
+// returns (allowed range 1-100, defaults to 25) and page.Offset shifts the
+// window for subsequent pages. Use NewPageSettings() to defer both to the
+// server's defaults.
+func (c *WS2Client) SearchAnnotation(searchTerm string, page PageSettings) (*AnnotationResponse, error) {
+
+	result := annotationResult{}
+	if err := c.getReqeust(&result, searchParams(searchTerm, page), "/annotation"); err != nil {
+		return nil, err
+	}
+	return &result.Response, nil
+}
+
+// SearchCDStub queries MusicBrainz' Search Server for CD stubs.
+// searchTerm follows the Apache Lucene syntax. For a list of all valid
+// search fields visit
+// https://musicbrainz.org/doc/Development/XML_Web_Service/Version_2/Search#CDStub
+// page controls pagination: page.Limit caps how many entries the server
+// returns (allowed range 1-100, defaults to 25) and page.Offset shifts the
+// window for subsequent pages. Use NewPageSettings() to defer both to the
+// server's defaults.
+func (c *WS2Client) SearchCDStub(searchTerm string, page PageSettings) (*CDStubResponse, error) {
+
+	result := cdStubResult{}
+	if err := c.getReqeust(&result, searchParams(searchTerm, page), "/cdstub"); err != nil {
+		return nil, err
+	}
+	return &result.Response, nil
+}
+
+// SearchRecordingContext is like SearchRecording but takes a context.Context that can
+// cancel the request, e.g. while the client is blocked on the rate limiter.
+func (c *WS2Client) SearchRecordingContext(ctx context.Context, searchTerm string, page PageSettings) (*RecordingResponse, error) {
+
+	result := recordingResult{}
+	if err := c.getReqeustContext(ctx, &result, searchParams(searchTerm, page), "/recording"); err != nil {
+		return nil, err
+	}
+	return &result.Response, nil
+
+}
+
+// SearchWorkContext is like SearchWork but takes a context.Context that can
+// cancel the request, e.g. while the client is blocked on the rate limiter.
+func (c *WS2Client) SearchWorkContext(ctx context.Context, searchTerm string, page PageSettings) (*WorkResponse, error) {
+
+	result := workResult{}
+	if err := c.getReqeustContext(ctx, &result, searchParams(searchTerm, page), "/work"); err != nil {
+		return nil, err
+	}
+	return &result.Response, nil
+
+}
+
+// SearchLabelContext is like SearchLabel but takes a context.Context that can
+// cancel the request, e.g. while the client is blocked on the rate limiter.
+func (c *WS2Client) SearchLabelContext(ctx context.Context, searchTerm string, page PageSettings) (*LabelResponse, error) {
+
+	result := labelResult{}
+	if err := c.getReqeustContext(ctx, &result, searchParams(searchTerm, page), "/label"); err != nil {
+		return nil, err
+	}
+	return &result.Response, nil
+
+}
+
+// SearchAreaContext is like SearchArea but takes a context.Context that can
+// cancel the request, e.g. while the client is blocked on the rate limiter.
+func (c *WS2Client) SearchAreaContext(ctx context.Context, searchTerm string, page PageSettings) (*AreaResponse, error) {
+
+	result := areaResult{}
+	if err := c.getReqeustContext(ctx, &result, searchParams(searchTerm, page), "/area"); err != nil {
+		return nil, err
+	}
+	return &result.Response, nil
+
+}
+
+// SearchEventContext is like SearchEvent but takes a context.Context that can
+// cancel the request, e.g. while the client is blocked on the rate limiter.
+func (c *WS2Client) SearchEventContext(ctx context.Context, searchTerm string, page PageSettings) (*EventResponse, error) {
+
+	result := eventResult{}
+	if err := c.getReqeustContext(ctx, &result, searchParams(searchTerm, page), "/event"); err != nil {
+		return nil, err
+	}
+	return &result.Response, nil
+
+}
+
+// SearchInstrumentContext is like SearchInstrument but takes a context.Context that can
+// cancel the request, e.g. while the client is blocked on the rate limiter.
+func (c *WS2Client) SearchInstrumentContext(ctx context.Context, searchTerm string, page PageSettings) (*InstrumentResponse, error) {
+
+	result := instrumentResult{}
+	if err := c.getReqeustContext(ctx, &result, searchParams(searchTerm, page), "/instrument"); err != nil {
+		return nil, err
+	}
+	return &result.Response, nil
+
+}
+
+// SearchSeriesContext is like SearchSeries but takes a context.Context that can
+// cancel the request, e.g. while the client is blocked on the rate limiter.
+func (c *WS2Client) SearchSeriesContext(ctx context.Context, searchTerm string, page PageSettings) (*SeriesResponse, error) {
+
+	result := seriesResult{}
+	if err := c.getReqeustContext(ctx, &result, searchParams(searchTerm, page), "/series"); err != nil {
+		return nil, err
+	}
+	return &result.Response, nil
+
+}
+
+// SearchURLContext is like SearchURL but takes a context.Context that can
+// cancel the request, e.g. while the client is blocked on the rate limiter.
+func (c *WS2Client) SearchURLContext(ctx context.Context, searchTerm string, page PageSettings) (*URLResponse, error) {
+
+	result := urlResult{}
+	if err := c.getReqeustContext(ctx, &result, searchParams(searchTerm, page), "/url"); err != nil {
+		return nil, err
+	}
+	return &result.Response, nil
+
+}
+
+// SearchAnnotationContext is like SearchAnnotation but takes a context.Context that can
+// cancel the request, e.g. while the client is blocked on the rate limiter.
+func (c *WS2Client) SearchAnnotationContext(ctx context.Context, searchTerm string, page PageSettings) (*AnnotationResponse, error) {
+
+	result := annotationResult{}
+	if err := c.getReqeustContext(ctx, &result, searchParams(searchTerm, page), "/annotation"); err != nil {
+		return nil, err
+	}
+	return &result.Response, nil
+
+}
+
+// SearchCDStubContext is like SearchCDStub but takes a context.Context that can
+// cancel the request, e.g. while the client is blocked on the rate limiter.
+func (c *WS2Client) SearchCDStubContext(ctx context.Context, searchTerm string, page PageSettings) (*CDStubResponse, error) {
+
+	result := cdStubResult{}
+	if err := c.getReqeustContext(ctx, &result, searchParams(searchTerm, page), "/cdstub"); err != nil {
+		return nil, err
+	}
+	return &result.Response, nil
+
+}
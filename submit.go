@@ -0,0 +1,382 @@
+/*
+ * Copyright (c) 2014 Michael Wendland
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+ * FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+ * IN THE SOFTWARE.
+ *
+ * 	Authors:
+ * 		Michael Wendland <michael@michiwend.com>
+ */
+
+package gomusicbrainz
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// mmdXmlns is the XML namespace of the WS2 submission payloads.
+const mmdXmlns = "http://musicbrainz.org/ns/mmd-2.0#"
+
+// authenticatedRequest performs req against the WS2 server using c.Auth,
+// waiting on the rate limiter first like every other request. It returns an
+// error if no Auth is configured, since every caller of this function talks
+// to an endpoint that requires a logged in user.
+func (c *WS2Client) authenticatedRequest(ctx context.Context, method, endpoint string, params url.Values, body []byte) error {
+	if c.Auth == nil {
+		return fmt.Errorf("gomusicbrainz: %s %s requires a WS2Client.Auth to be configured", method, endpoint)
+	}
+
+	if err := c.rateLimiter().Wait(ctx); err != nil {
+		return err
+	}
+
+	if params == nil {
+		params = url.Values{}
+	}
+	params.Set("client", c.clientID)
+
+	reqURL := c.WS2RootURL.String() + endpoint + "?" + params.Encode()
+
+	var bodyReader *bytes.Reader
+	if body != nil {
+		bodyReader = bytes.NewReader(body)
+	} else {
+		bodyReader = bytes.NewReader(nil)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, reqURL, bodyReader)
+	if err != nil {
+		return &WS2Error{Endpoint: endpoint, Err: err}
+	}
+	req.Header.Set("User-Agent", c.userAgentHeader)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/xml; charset=utf-8")
+	}
+
+	client := &http.Client{}
+	resp, err := c.Auth.authenticatedDo(client, req)
+	if err != nil {
+		return &WS2Error{Endpoint: endpoint, Err: err}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		// The request never sets fmt=json, so the server answers in XML.
+		return newWS2StatusError(endpoint, resp.StatusCode, FormatXML, resp.Body)
+	}
+	return nil
+}
+
+// userRatingLookupResult is named (rather than an anonymous struct) so it
+// can implement UnmarshalJSON: like the other single-entity lookups, the
+// JSON user-ratings response has no "recording" wrapping key.
+type userRatingLookupResult struct {
+	Recording UserRating `xml:"recording" json:"recording"`
+}
+
+func (r *userRatingLookupResult) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, &r.Recording)
+}
+
+// userTagsLookupResult decodes GET /recording/<mbid>?inc=user-tags. Unlike
+// a Tag search, the response isn't a count/offset/tags envelope: the XML
+// nests user-tag-list under the recording itself
+// (<metadata><recording><user-tag-list><user-tag><name>), and the JSON
+// puts a flat "user-tags" key on the recording object. tagResult's
+// TagResponse shape doesn't match either, so it previously decoded to a
+// silent zero value.
+type userTagsLookupResult struct {
+	Recording struct {
+		Tags []Tag `xml:"user-tag-list>user-tag" json:"user-tags"`
+	} `xml:"recording"`
+}
+
+func (r *userTagsLookupResult) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, &r.Recording)
+}
+
+// LookupUserTags returns the authenticated user's own tags for the
+// Recording identified by mbid. It requires c.Auth to be set.
+func (c *WS2Client) LookupUserTags(mbid string) ([]Tag, error) {
+	return c.LookupUserTagsContext(context.Background(), mbid)
+}
+
+// LookupUserTagsContext is like LookupUserTags but takes a context.Context
+// that can cancel the request.
+func (c *WS2Client) LookupUserTagsContext(ctx context.Context, mbid string) ([]Tag, error) {
+	if c.Auth == nil {
+		return nil, fmt.Errorf("gomusicbrainz: LookupUserTags requires a WS2Client.Auth to be configured")
+	}
+
+	result := userTagsLookupResult{}
+	params := url.Values{"inc": {"user-tags"}}
+	endpoint := "/recording/" + mbid
+
+	if err := c.getAuthenticatedGET(ctx, &result, params, endpoint); err != nil {
+		return nil, err
+	}
+	return result.Recording.Tags, nil
+}
+
+// LookupUserRatings returns the authenticated user's own rating for the
+// Recording identified by mbid. It requires c.Auth to be set.
+func (c *WS2Client) LookupUserRatings(mbid string) (*UserRating, error) {
+	return c.LookupUserRatingsContext(context.Background(), mbid)
+}
+
+// LookupUserRatingsContext is like LookupUserRatings but takes a
+// context.Context that can cancel the request.
+func (c *WS2Client) LookupUserRatingsContext(ctx context.Context, mbid string) (*UserRating, error) {
+	if c.Auth == nil {
+		return nil, fmt.Errorf("gomusicbrainz: LookupUserRatings requires a WS2Client.Auth to be configured")
+	}
+
+	result := userRatingLookupResult{}
+	params := url.Values{"inc": {"user-ratings"}}
+	endpoint := "/recording/" + mbid
+
+	if err := c.getAuthenticatedGET(ctx, &result, params, endpoint); err != nil {
+		return nil, err
+	}
+	return &result.Recording, nil
+}
+
+// getAuthenticatedGET performs an authenticated GET request, decoding the
+// XML or JSON response into data depending on c.Format.
+func (c *WS2Client) getAuthenticatedGET(ctx context.Context, data interface{}, params url.Values, endpoint string) error {
+	if err := c.rateLimiter().Wait(ctx); err != nil {
+		return err
+	}
+	if c.Format == FormatJSON {
+		params.Set("fmt", "json")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", c.WS2RootURL.String()+endpoint+"?"+params.Encode(), nil)
+	if err != nil {
+		return &WS2Error{Endpoint: endpoint, Err: err}
+	}
+	req.Header.Set("User-Agent", c.userAgentHeader)
+
+	resp, err := c.Auth.authenticatedDo(&http.Client{}, req)
+	if err != nil {
+		return &WS2Error{Endpoint: endpoint, Err: err}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return newWS2StatusError(endpoint, resp.StatusCode, c.Format, resp.Body)
+	}
+
+	if c.Format == FormatJSON {
+		return json.NewDecoder(resp.Body).Decode(data)
+	}
+	return xml.NewDecoder(resp.Body).Decode(data)
+}
+
+// SubmitTags submits user tags for the Recording identified by mbid,
+// replacing any tags the user had previously set on it. The submission
+// body is always XML, regardless of c.Format: WS2 submission endpoints
+// are documented and tested against the XML mmd-2.0 schema only.
+func (c *WS2Client) SubmitTags(recordingMBID string, tags ...string) error {
+	return c.SubmitTagsContext(context.Background(), recordingMBID, tags...)
+}
+
+// SubmitTagsContext is like SubmitTags but takes a context.Context that can
+// cancel the request.
+func (c *WS2Client) SubmitTagsContext(ctx context.Context, recordingMBID string, tags ...string) error {
+	userTags := make([]submittedTag, len(tags))
+	for i, t := range tags {
+		userTags[i] = submittedTag{Name: t}
+	}
+
+	body, err := xml.Marshal(tagSubmission{
+		Xmlns: mmdXmlns,
+		Recordings: []submittedRecordingTags{{
+			ID:   recordingMBID,
+			Tags: userTags,
+		}},
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.authenticatedRequest(ctx, "POST", "/recording", nil, body)
+}
+
+// SubmitRatings submits a 0-100 (in steps of 20) user rating for the
+// Recording identified by mbid. The submission body is always XML,
+// regardless of c.Format; see SubmitTags.
+func (c *WS2Client) SubmitRatings(recordingMBID string, rating int) error {
+	return c.SubmitRatingsContext(context.Background(), recordingMBID, rating)
+}
+
+// SubmitRatingsContext is like SubmitRatings but takes a context.Context
+// that can cancel the request.
+func (c *WS2Client) SubmitRatingsContext(ctx context.Context, recordingMBID string, rating int) error {
+	body, err := xml.Marshal(ratingSubmission{
+		Xmlns: mmdXmlns,
+		Recordings: []submittedRecordingRating{{
+			ID:     recordingMBID,
+			Rating: rating,
+		}},
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.authenticatedRequest(ctx, "POST", "/recording", nil, body)
+}
+
+// SubmitISRCs submits additional ISRCs for the Recording identified by
+// mbid. The submission body is always XML, regardless of c.Format; see
+// SubmitTags.
+func (c *WS2Client) SubmitISRCs(recordingMBID string, isrcs ...string) error {
+	return c.SubmitISRCsContext(context.Background(), recordingMBID, isrcs...)
+}
+
+// SubmitISRCsContext is like SubmitISRCs but takes a context.Context that
+// can cancel the request.
+func (c *WS2Client) SubmitISRCsContext(ctx context.Context, recordingMBID string, isrcs ...string) error {
+	submittedISRCs := make([]submittedISRC, len(isrcs))
+	for i, isrc := range isrcs {
+		submittedISRCs[i] = submittedISRC{ID: isrc}
+	}
+
+	body, err := xml.Marshal(isrcSubmission{
+		Xmlns: mmdXmlns,
+		Recordings: []submittedRecordingISRCs{{
+			ID:    recordingMBID,
+			ISRCs: submittedISRCs,
+		}},
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.authenticatedRequest(ctx, "POST", "/recording", nil, body)
+}
+
+// SubmitBarcodes submits a barcode for the Release identified by mbid.
+// The submission body is always XML, regardless of c.Format; see
+// SubmitTags.
+func (c *WS2Client) SubmitBarcodes(releaseMBID string, barcode string) error {
+	return c.SubmitBarcodesContext(context.Background(), releaseMBID, barcode)
+}
+
+// SubmitBarcodesContext is like SubmitBarcodes but takes a context.Context
+// that can cancel the request.
+func (c *WS2Client) SubmitBarcodesContext(ctx context.Context, releaseMBID string, barcode string) error {
+	body, err := xml.Marshal(barcodeSubmission{
+		Xmlns: mmdXmlns,
+		Releases: []submittedReleaseBarcode{{
+			ID:      releaseMBID,
+			Barcode: barcode,
+		}},
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.authenticatedRequest(ctx, "POST", "/release", nil, body)
+}
+
+// AddToCollection adds the entities identified by mbids (of the given
+// entityType, e.g. "releases" or "artists") to the collection identified by
+// collectionMBID.
+func (c *WS2Client) AddToCollection(collectionMBID, entityType string, mbids ...string) error {
+	return c.AddToCollectionContext(context.Background(), collectionMBID, entityType, mbids...)
+}
+
+// AddToCollectionContext is like AddToCollection but takes a
+// context.Context that can cancel the request.
+func (c *WS2Client) AddToCollectionContext(ctx context.Context, collectionMBID, entityType string, mbids ...string) error {
+	endpoint := "/collection/" + collectionMBID + "/" + entityType + "/" + strings.Join(mbids, ";")
+	return c.authenticatedRequest(ctx, "PUT", endpoint, nil, nil)
+}
+
+// RemoveFromCollection removes the entities identified by mbids (of the
+// given entityType) from the collection identified by collectionMBID.
+func (c *WS2Client) RemoveFromCollection(collectionMBID, entityType string, mbids ...string) error {
+	return c.RemoveFromCollectionContext(context.Background(), collectionMBID, entityType, mbids...)
+}
+
+// RemoveFromCollectionContext is like RemoveFromCollection but takes a
+// context.Context that can cancel the request.
+func (c *WS2Client) RemoveFromCollectionContext(ctx context.Context, collectionMBID, entityType string, mbids ...string) error {
+	endpoint := "/collection/" + collectionMBID + "/" + entityType + "/" + strings.Join(mbids, ";")
+	return c.authenticatedRequest(ctx, "DELETE", endpoint, nil, nil)
+}
+
+type tagSubmission struct {
+	XMLName    xml.Name                 `xml:"metadata"`
+	Xmlns      string                   `xml:"xmlns,attr"`
+	Recordings []submittedRecordingTags `xml:"recording-list>recording"`
+}
+
+type submittedRecordingTags struct {
+	ID   string         `xml:"id,attr"`
+	Tags []submittedTag `xml:"user-tag-list>user-tag"`
+}
+
+type submittedTag struct {
+	Name string `xml:"name"`
+}
+
+type ratingSubmission struct {
+	XMLName    xml.Name                   `xml:"metadata"`
+	Xmlns      string                     `xml:"xmlns,attr"`
+	Recordings []submittedRecordingRating `xml:"recording-list>recording"`
+}
+
+type submittedRecordingRating struct {
+	ID     string `xml:"id,attr"`
+	Rating int    `xml:"user-rating"`
+}
+
+type isrcSubmission struct {
+	XMLName    xml.Name                  `xml:"metadata"`
+	Xmlns      string                    `xml:"xmlns,attr"`
+	Recordings []submittedRecordingISRCs `xml:"recording-list>recording"`
+}
+
+type submittedRecordingISRCs struct {
+	ID    string          `xml:"id,attr"`
+	ISRCs []submittedISRC `xml:"isrc-list>isrc"`
+}
+
+type submittedISRC struct {
+	ID string `xml:"id,attr"`
+}
+
+type barcodeSubmission struct {
+	XMLName  xml.Name                  `xml:"metadata"`
+	Xmlns    string                    `xml:"xmlns,attr"`
+	Releases []submittedReleaseBarcode `xml:"release-list>release"`
+}
+
+type submittedReleaseBarcode struct {
+	ID      string `xml:"id,attr"`
+	Barcode string `xml:"barcode"`
+}
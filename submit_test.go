@@ -0,0 +1,79 @@
+/*
+ * Copyright (c) 2014 Michael Wendland
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+ * FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+ * IN THE SOFTWARE.
+ *
+ * 	Authors:
+ * 		Michael Wendland <michael@michiwend.com>
+ */
+
+package gomusicbrainz
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type staticTokenSource string
+
+func (s staticTokenSource) Token() (string, error) { return string(s), nil }
+
+// TestLookupUserTagsJSON proves LookupUserTagsContext decodes the
+// recording-scoped user-tags shape instead of the Tag-search envelope.
+func TestLookupUserTagsJSON(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id": "recording-mbid", "user-tags": [{"name": "favourite"}]}`))
+	}))
+	defer server.Close()
+
+	c := newTestClient(server)
+	c.SetFormat(FormatJSON)
+	c.Auth = OAuth2Auth{TokenSource: staticTokenSource("token")}
+
+	tags, err := c.LookupUserTags("recording-mbid")
+	if err != nil {
+		t.Fatalf("LookupUserTags: %v", err)
+	}
+	if len(tags) != 1 || tags[0].Name != "favourite" {
+		t.Errorf("tags = %+v, want [{favourite 0}]", tags)
+	}
+}
+
+// TestLookupUserTagsXML proves the XML path decodes
+// <recording><user-tag-list><user-tag><name> the same way.
+func TestLookupUserTagsXML(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/xml")
+		w.Write([]byte(`<metadata><recording id="recording-mbid"><user-tag-list><user-tag><name>favourite</name></user-tag></user-tag-list></recording></metadata>`))
+	}))
+	defer server.Close()
+
+	c := newTestClient(server)
+	c.Auth = OAuth2Auth{TokenSource: staticTokenSource("token")}
+
+	tags, err := c.LookupUserTags("recording-mbid")
+	if err != nil {
+		t.Fatalf("LookupUserTags: %v", err)
+	}
+	if len(tags) != 1 || tags[0].Name != "favourite" {
+		t.Errorf("tags = %+v, want [{favourite 0}]", tags)
+	}
+}